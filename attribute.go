@@ -30,3 +30,13 @@ const (
 	AttrUnderline           = Attribute(termbox.AttrUnderline)
 	AttrReverse             = Attribute(termbox.AttrReverse)
 )
+
+// Additional text style attributes. They are kept in the views' buffers and
+// honored by the exporters (ANSIBuffer, Dump, ExportHTML, ExportSVG), but the
+// termbox backend cannot render them, so they are not displayed on screen.
+const (
+	AttrItalic Attribute = AttrReverse << (iota + 1)
+	AttrDim
+	AttrStrikethrough
+	AttrBlink
+)
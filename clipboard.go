@@ -0,0 +1,41 @@
+// Copyright 2014 The gocui Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gocui
+
+import (
+	"encoding/base64"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// CopyToClipboard copies text to the system clipboard, using the OSC 52
+// escape sequence, which is supported by many terminals and also works over
+// SSH. Inside tmux, the sequence is passed through to the outer terminal.
+//
+// Given that there is no way to know whether the terminal supports OSC 52,
+// if ClipboardCommand is set, the text is always copied with it as well, even
+// if the sequence could not be written. Then, only the errors of the command
+// are returned.
+func (g *Gui) CopyToClipboard(text string) error {
+	err := writeTerminal(osc52(text, os.Getenv("TMUX") != ""))
+	if len(g.ClipboardCommand) == 0 {
+		return err
+	}
+	cmd := exec.Command(g.ClipboardCommand[0], g.ClipboardCommand[1:]...)
+	cmd.Stdin = strings.NewReader(text)
+	return cmd.Run()
+}
+
+// osc52 returns the OSC 52 escape sequence that sets the clipboard to text.
+// If tmux is true, the sequence is wrapped so tmux passes it through to the
+// terminal.
+func osc52(text string, tmux bool) string {
+	seq := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\a"
+	if tmux {
+		seq = "\x1bPtmux;\x1b" + seq + "\x1b\\"
+	}
+	return seq
+}
@@ -31,6 +31,10 @@ main views, being possible to freely change them during execution. Also, it is
 important to mention that a main loop iteration is executed on each reported
 event (key-press, mouse event, window resize, etc).
 
+Instead of computing the coordinates of the views by hand, the layout package
+provides a Manager that arranges views in nested rows and columns with fixed,
+percentage and fractional sizes.
+
 GUIs are composed by Views, you can think of it as buffers. Views implement the
 io.ReadWriter interface, so you can just write to them if you want to modify
 their content. The same is valid for reading.
@@ -58,6 +62,56 @@ Configure keybindings:
 		// handle error
 	}
 
+Keys can also be given as human-readable specifications, which are useful for
+configuration files and help screens (see ParseKey and KeyPress.String):
+
+	if err := g.SetKeybinding("", "ctrl+s", gocui.ModNone, save); err != nil {
+		// handle error
+	}
+
+Keybindings can be restricted to an input mode, so the same key can have
+different meanings, like in vi:
+
+	g.SetModeKeybinding("normal", "", 'i', gocui.ModNone, enterInsertMode)
+	g.SetModeKeybinding("insert", "", gocui.KeyEsc, gocui.ModNone, enterNormalMode)
+
+	func enterInsertMode(g gocui.Guier, v gocui.Viewer) error {
+		return g.SetMode("insert")
+	}
+
+A Keymap allows to register handlers by action name and to let users remap
+the keys that trigger them with a JSON configuration:
+
+	km := gocui.NewKeymap()
+	km.SetAction("quit", quit)
+	km.Bind("", "ctrl+c", "quit")
+	if err := km.LoadFile("keymap.json"); err != nil && !os.IsNotExist(err) {
+		// handle error
+	}
+	if err := km.Apply(g); err != nil {
+		// handle error
+	}
+
+Keybindings of a view take precedence over global keybindings (viewname ""),
+and only the first matching handler is called. A handler can return
+gocui.ErrPropagate to let the event reach the next keybinding and, finally,
+the editor of the current view. Global keybindings of runes without modifiers
+are ignored in editable views, so the text typed by the user is not
+swallowed.
+
+Keybindings can also be triggered by sequences of key-presses:
+
+	seq := []gocui.KeyPress{{Key: gocui.KeyCtrlX}, {Key: gocui.KeyCtrlS}}
+	if err := g.SetKeySequence("", seq, save); err != nil {
+		// handle error
+	}
+
+A KeyPredicate matches a whole class of keys, like any digit or any rune.
+Exact keybindings take precedence over the ones of predicates:
+
+	g.SetEventKeybinding("list", gocui.RuneRange('0', '9'), gocui.ModNone, addToCount)
+	g.SetEventKeybinding("list", gocui.PrintableRune, gocui.ModNone, typeToSearch)
+
 gocui implements full mouse support that can be enabled with:
 
 	g.Mouse = true
@@ -68,6 +122,94 @@ Mouse events are handled like any other keybinding:
 		// handle error
 	}
 
+Handlers created with SetEventKeybinding also receive the Event that
+triggered them, including the position of the mouse and the number of
+consecutive clicks:
+
+	err := g.SetEventKeybinding("viewname", gocui.MouseLeft, gocui.ModNone,
+		func(g gocui.Guier, v gocui.Viewer, e *gocui.Event) error {
+			if e.Clicks == 2 {
+				return open(v, e.ViewX, e.ViewY)
+			}
+			return nil
+		})
+
+gocui also synthesizes drag (MouseDragStart, MouseDrag, MouseDragEnd),
+hover (MouseHover) and multiple click (MouseDoubleClick, MouseTripleClick)
+events. Drag events are sent to the view where the drag started, with the
+starting position in Event.DragX and Event.DragY. Hover events are only
+reported if the motion of the mouse is tracked:
+
+	g.MouseMotion = true
+
+Clickable regions can be defined inside the buffer of a view, for buttons or
+links rendered as text. They are tracked in buffer coordinates, so they
+scroll with the content:
+
+	fmt.Fprint(v, "Status: ")
+	v.WriteRegion("retry", "[Retry]", func(g gocui.Guier, v gocui.Viewer, e *gocui.Event) error {
+		return retry()
+	})
+
+Clicked views can get the focus automatically, depending on the focus policy
+of the GUI. Focus changes are reported to the focus change handler:
+
+	g.FocusOnClick = gocui.FocusFocusable
+	g.SetFocusChangeHandler(func(g gocui.Guier, from, to gocui.Viewer) error {
+		return updateStatusBar(to)
+	})
+
+FocusNext and FocusPrevious move the focus between the focusable views,
+following their TabIndex, and call their focus-in and focus-out handlers:
+
+	g.SetKeybinding("", gocui.KeyTab, gocui.ModNone, func(g gocui.Guier, v gocui.Viewer) error {
+		_, err := g.FocusNext()
+		return err
+	})
+
+FocusLeft, FocusRight, FocusUp and FocusDown move the focus to the nearest
+view in that direction, based on the bounds of the views, which is handy for
+grid layouts.
+
+termbox reports Shift-Tab as the sequence "alt+[ Z" ("esc [ Z" if InputEsc is
+enabled), which can be bound to FocusPrevious.
+
+Views scroll with the mouse wheel if View.WheelLines is greater than 0, and
+wheel events are not handled by a keybinding. Scrolling up pauses
+Autoscroll until the view is scrolled back to the bottom.
+
+Views can let the user resize them by dragging the edges of their frame
+(View.Resizable), or move them by dragging its top edge (View.Movable). The
+Layout function should use the bounds reported to the view bounds handler:
+
+	g.SetViewBoundsHandler(func(g gocui.Guier, name string, x0, y0, x1, y1 int) error {
+		bounds[name] = [4]int{x0, y0, x1, y1}
+		return nil
+	})
+
+If View.Selectable is true, the user can select text by dragging the mouse
+over the view. The selection can also be changed with SetSelection and
+ExtendSelection, and its text is returned by SelectedText. The terminal does
+not report Shift along with mouse events, so applications that want
+shift-click behaviour can bind ExtendSelection to another mouse event.
+
+CopyToClipboard copies text to the system clipboard with the OSC 52 escape
+sequence, which works over SSH and inside tmux. A helper command can be
+configured for terminals that do not support it:
+
+	g.ClipboardCommand = []string{"xclip", "-selection", "clipboard"}
+	err := g.CopyToClipboard(v.SelectedText())
+
+If the bracketed paste mode is enabled, and the terminal supports it, text
+pasted by the user is inserted at once in the current view, without
+triggering keybindings. Editors that implement PasteEditor receive the whole
+text in a single call. It is enabled with:
+
+	g.BracketedPaste = true
+
+In this mode, a key-press that could be the beginning of a paste marker,
+like ESC, is held back until the next one arrives, or for a few milliseconds.
+
 IMPORTANT: Views can only be created, destroyed or updated in three ways: from
 the Layout function within managers, from keybinding callbacks or via
 *Gui.Update(). The reason for this is that it allows gocui to be
@@ -113,6 +255,11 @@ Views allow to add colored text using ANSI colors. For example:
 
 	fmt.Fprintln(v, "\x1b[0;31mHello world")
 
+Text styles are also supported: bold (1), dim (2), italic (3), underline (4),
+blink (5), reverse (7) and strikethrough (9). Dim, italic, blink and
+strikethrough are only kept in the buffers and the exports: they are not
+displayed on screen.
+
 For more information, see the examples in folder "_examples/".
 */
 package gocui
@@ -13,6 +13,14 @@ type Editor interface {
 	Edit(v Viewer, key Key, ch rune, mod Modifier)
 }
 
+// PasteEditor is implemented by editors that handle the text pasted by the
+// user at once, when the bracketed paste mode is enabled. Other editors
+// receive the pasted text rune by rune.
+type PasteEditor interface {
+	Editor
+	Paste(v Viewer, text string)
+}
+
 // The EditorFunc type is an adapter to allow the use of ordinary functions as
 // Editors. If f is a function with the appropriate signature, EditorFunc(f)
 // is an Editor object that calls f.
@@ -7,6 +7,7 @@ package gocui
 import (
 	"errors"
 	"strconv"
+	"strings"
 )
 
 type escapeInterpreter struct {
@@ -158,12 +159,7 @@ func (ei *escapeInterpreter) outputNormal() error {
 			ei.curBgColor = Attribute(p - 40 + 1)
 		case p == 49:
 			ei.curBgColor = ColorDefault
-		case p == 1:
-			ei.curFgColor |= AttrBold
-		case p == 4:
-			ei.curFgColor |= AttrUnderline
-		case p == 7:
-			ei.curFgColor |= AttrReverse
+		case ei.setStyle(p):
 		case p == 0:
 			ei.curFgColor = ColorDefault
 			ei.curBgColor = ColorDefault
@@ -187,14 +183,14 @@ func (ei *escapeInterpreter) output256() error {
 	if err != nil {
 		return errCSIParseError
 	}
-	if mode != 5 {
-		return ei.outputNormal()
-	}
-
 	fgbg, err := strconv.Atoi(ei.csiParam[0])
 	if err != nil {
 		return errCSIParseError
 	}
+	if mode != 5 || (fgbg != 38 && fgbg != 48) {
+		return ei.outputNormal()
+	}
+
 	color, err := strconv.Atoi(ei.csiParam[2])
 	if err != nil {
 		return errCSIParseError
@@ -209,15 +205,7 @@ func (ei *escapeInterpreter) output256() error {
 			if err != nil {
 				return errCSIParseError
 			}
-
-			switch {
-			case p == 1:
-				ei.curFgColor |= AttrBold
-			case p == 4:
-				ei.curFgColor |= AttrUnderline
-			case p == 7:
-				ei.curFgColor |= AttrReverse
-			}
+			ei.setStyle(p)
 		}
 	case 48:
 		ei.curBgColor = Attribute(color + 1)
@@ -227,3 +215,99 @@ func (ei *escapeInterpreter) output256() error {
 
 	return nil
 }
+
+// styleParams links the SGR parameters with the text style attributes they
+// enable.
+var styleParams = []struct {
+	param int
+	attr  Attribute
+}{
+	{1, AttrBold},
+	{2, AttrDim},
+	{3, AttrItalic},
+	{4, AttrUnderline},
+	{5, AttrBlink},
+	{7, AttrReverse},
+	{9, AttrStrikethrough},
+}
+
+// styleResetParams links the SGR parameters with the text style attributes
+// they disable.
+var styleResetParams = []struct {
+	param int
+	attr  Attribute
+}{
+	{22, AttrBold | AttrDim},
+	{23, AttrItalic},
+	{24, AttrUnderline},
+	{25, AttrBlink},
+	{27, AttrReverse},
+	{29, AttrStrikethrough},
+}
+
+// setStyle applies the text style SGR parameter p to the current foreground
+// attributes. It returns false if p is not a text style parameter.
+func (ei *escapeInterpreter) setStyle(p int) bool {
+	for _, sp := range styleParams {
+		if sp.param == p {
+			ei.curFgColor |= sp.attr
+			return true
+		}
+	}
+	for _, sp := range styleResetParams {
+		if sp.param == p {
+			ei.curFgColor &^= sp.attr
+			return true
+		}
+	}
+	return false
+}
+
+// escapeReset is the escape sequence that restores the default colors and
+// attributes.
+const escapeReset = "\x1b[0m"
+
+// colorMask selects the color part of an Attribute, leaving out the text
+// style attributes.
+const colorMask = AttrBold - 1
+
+// escapeSequence returns the escape sequence that makes an escapeInterpreter
+// set the given colors and attributes. Colors beyond the 8 basic ones are
+// encoded as 256-colors sequences.
+func escapeSequence(fgColor, bgColor Attribute) string {
+	fg, bg := fgColor&colorMask, bgColor&colorMask
+	attrs := attrParams(fgColor)
+
+	params := []string{"0"}
+	ext := ""
+	switch {
+	case fg == ColorDefault:
+		params = append(params, attrs...)
+	case fg <= ColorWhite:
+		params = append(params, strconv.Itoa(int(fg-ColorBlack)+30))
+		params = append(params, attrs...)
+	default:
+		p := append([]string{"38", "5", strconv.Itoa(int(fg) - 1)}, attrs...)
+		ext += "\x1b[" + strings.Join(p, ";") + "m"
+	}
+	switch {
+	case bg == ColorDefault:
+	case bg <= ColorWhite:
+		params = append(params, strconv.Itoa(int(bg-ColorBlack)+40))
+	default:
+		ext += "\x1b[48;5;" + strconv.Itoa(int(bg)-1) + "m"
+	}
+	return "\x1b[" + strings.Join(params, ";") + "m" + ext
+}
+
+// attrParams returns the SGR parameters of the text style attributes
+// contained in a given Attribute.
+func attrParams(a Attribute) []string {
+	var params []string
+	for _, sp := range styleParams {
+		if a&sp.attr != 0 {
+			params = append(params, strconv.Itoa(sp.param))
+		}
+	}
+	return params
+}
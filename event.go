@@ -0,0 +1,106 @@
+// Copyright 2014 The gocui Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gocui
+
+import (
+	"time"
+
+	"github.com/thermeon/termbox-go"
+)
+
+// clickInterval is the maximum time between two clicks of the same mouse
+// button for them to be consecutive.
+const clickInterval = 500 * time.Millisecond
+
+// Event describes the key-press or mouse event that triggered a keybinding.
+// It is passed to the handlers created with SetEventKeybinding.
+type Event struct {
+	// Key, Ch and Mod describe the last key-press. Keys contains the whole
+	// sequence of key-presses that matched the keybinding.
+	Key  Key
+	Ch   rune
+	Mod  Modifier
+	Keys []KeyPress
+
+	// MouseX and MouseY are the position of the mouse pointer, relative to
+	// the top-left corner of the terminal. ViewX and ViewY are the same
+	// position relative to the view. They are only set for mouse events.
+	MouseX, MouseY int
+	ViewX, ViewY   int
+
+	// Clicks is the number of consecutive clicks of the same mouse button
+	// at the same position, e.g. 2 for a double-click.
+	Clicks int
+
+	// Region is the name of the clickable region of the view under the mouse
+	// pointer, if any. See View.SetRegion.
+	Region string
+
+	// DragX and DragY are the position, relative to the top-left corner of
+	// the terminal, where the drag started. They are only set for drag
+	// events.
+	DragX, DragY int
+
+	// Time is the moment when the event was received.
+	Time time.Time
+}
+
+// newEvent returns the Event corresponding to a termbox event.
+func newEvent(ev *termbox.Event) *Event {
+	e := &Event{
+		Key:  Key(ev.Key),
+		Ch:   ev.Ch,
+		Mod:  Modifier(ev.Mod),
+		Time: time.Now(),
+	}
+	if ev.Type == termbox.EventMouse {
+		e.MouseX, e.MouseY = ev.MouseX, ev.MouseY
+	}
+	return e
+}
+
+// newKeyEvent returns the Event corresponding to a key-press.
+func newKeyEvent(kp KeyPress) *Event {
+	return &Event{Key: kp.Key, Ch: kp.Ch, Mod: kp.Mod, Time: time.Now()}
+}
+
+// keyPress returns the key-press of the event.
+func (e *Event) keyPress() KeyPress {
+	return KeyPress{Key: e.Key, Ch: e.Ch, Mod: e.Mod}
+}
+
+// IsMouse returns if the event was produced by the mouse.
+func (e *Event) IsMouse() bool {
+	return e.keyPress().isMouse()
+}
+
+// countClicks sets the number of consecutive clicks of a mouse event.
+func (g *Gui) countClicks(e *Event) {
+	switch e.Key {
+	case MouseLeft, MouseMiddle, MouseRight:
+	default:
+		return
+	}
+
+	last := g.lastClick
+	if last != nil && last.Key == e.Key && last.MouseX == e.MouseX && last.MouseY == e.MouseY &&
+		e.Time.Sub(last.Time) <= clickInterval {
+		e.Clicks = last.Clicks + 1
+	} else {
+		e.Clicks = 1
+	}
+	g.lastClick = e
+}
+
+// eventHandler adapts a keybinding handler to the signature of the handlers
+// that receive an Event.
+func eventHandler(handler func(Guier, Viewer) error) func(Guier, Viewer, *Event) error {
+	if handler == nil {
+		return nil
+	}
+	return func(g Guier, v Viewer, _ *Event) error {
+		return handler(g, v)
+	}
+}
@@ -0,0 +1,316 @@
+// Copyright 2014 The gocui Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gocui
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/thermeon/termbox-go"
+)
+
+// Colors used by the exporters for cells with ColorDefault.
+const (
+	exportFgColor = "#c0c0c0"
+	exportBgColor = "#000000"
+)
+
+// Dimensions, in pixels, of the cells of the SVG documents.
+const (
+	svgCellWidth  = 8
+	svgCellHeight = 16
+	svgFontSize   = 13
+	svgBaseline   = 12
+)
+
+// basicColors contains the first 16 colors of the xterm palette.
+var basicColors = [16]string{
+	"#000000", "#800000", "#008000", "#808000",
+	"#000080", "#800080", "#008080", "#c0c0c0",
+	"#808080", "#ff0000", "#00ff00", "#ffff00",
+	"#0000ff", "#ff00ff", "#00ffff", "#ffffff",
+}
+
+// colorHex returns the hexadecimal RGB representation of the color contained
+// in a given Attribute, or def if it is ColorDefault.
+func colorHex(a Attribute, def string) string {
+	idx := int(a&colorMask) - 1
+	switch {
+	case idx < 0:
+		return def
+	case idx < 16:
+		return basicColors[idx]
+	case idx < 232:
+		levels := [6]int{0, 95, 135, 175, 215, 255}
+		idx -= 16
+		return fmt.Sprintf("#%02x%02x%02x", levels[idx/36], levels[idx/6%6], levels[idx%6])
+	default:
+		grey := 8 + (idx-232)*10
+		return fmt.Sprintf("#%02x%02x%02x", grey, grey, grey)
+	}
+}
+
+// blendHex returns the color halfway between two colors in hexadecimal RGB
+// representation.
+func blendHex(a, b string) string {
+	var ra, ga, ba, rb, gb, bb int
+	fmt.Sscanf(a, "#%02x%02x%02x", &ra, &ga, &ba)
+	fmt.Sscanf(b, "#%02x%02x%02x", &rb, &gb, &bb)
+	return fmt.Sprintf("#%02x%02x%02x", (ra+rb)/2, (ga+gb)/2, (ba+bb)/2)
+}
+
+// cellStyle describes how a cell is rendered by the exporters.
+type cellStyle struct {
+	fg, bg        string
+	bold          bool
+	italic        bool
+	underline     bool
+	strikethrough bool
+	blink         bool
+}
+
+// newCellStyle returns the cellStyle corresponding to the given colors.
+func newCellStyle(fgColor, bgColor Attribute) cellStyle {
+	s := cellStyle{
+		fg:            colorHex(fgColor, exportFgColor),
+		bg:            colorHex(bgColor, exportBgColor),
+		bold:          fgColor&AttrBold != 0,
+		italic:        fgColor&AttrItalic != 0,
+		underline:     fgColor&AttrUnderline != 0,
+		strikethrough: fgColor&AttrStrikethrough != 0,
+		blink:         fgColor&AttrBlink != 0,
+	}
+	if fgColor&AttrReverse != 0 {
+		s.fg, s.bg = s.bg, s.fg
+	}
+	if fgColor&AttrDim != 0 {
+		s.fg = blendHex(s.fg, s.bg)
+	}
+	return s
+}
+
+// decoration returns the value of the text-decoration property of the style,
+// or "" if the text is not decorated.
+func (s cellStyle) decoration() string {
+	var decs []string
+	if s.underline {
+		decs = append(decs, "underline")
+	}
+	if s.strikethrough {
+		decs = append(decs, "line-through")
+	}
+	if s.blink {
+		decs = append(decs, "blink")
+	}
+	return strings.Join(decs, " ")
+}
+
+// css returns the CSS declarations of the style.
+func (s cellStyle) css() string {
+	str := "color:" + s.fg + ";background-color:" + s.bg
+	if s.bold {
+		str += ";font-weight:bold"
+	}
+	if s.italic {
+		str += ";font-style:italic"
+	}
+	if dec := s.decoration(); dec != "" {
+		str += ";text-decoration:" + dec
+	}
+	return str
+}
+
+// exportRun is a horizontal sequence of cells that share the same style.
+type exportRun struct {
+	x     int
+	text  string
+	style cellStyle
+}
+
+// exportRows returns the runs of cells contained in the rectangle of the
+// screen delimited by (x0, y0) and (x1, y1), both included.
+func (g *Gui) exportRows(x0, y0, x1, y1 int) [][]exportRun {
+	if x0 < 0 {
+		x0 = 0
+	}
+	if y0 < 0 {
+		y0 = 0
+	}
+	if x1 >= g.maxX {
+		x1 = g.maxX - 1
+	}
+	if y1 >= g.maxY {
+		y1 = g.maxY - 1
+	}
+
+	buf := termbox.CellBuffer()
+	var rows [][]exportRun
+	for y := y0; y <= y1; y++ {
+		var runs []exportRun
+		for x := x0; x <= x1; x++ {
+			c := buf[y*g.maxX+x]
+			ch := c.Ch
+			if ch == 0 {
+				ch = ' '
+			}
+			s := newCellStyle(Attribute(c.Fg), Attribute(c.Bg))
+			if n := len(runs); n > 0 && runs[n-1].style == s {
+				runs[n-1].text += string(ch)
+				continue
+			}
+			runs = append(runs, exportRun{x: x - x0, text: string(ch), style: s})
+		}
+		rows = append(rows, runs)
+	}
+	return rows
+}
+
+// viewRect returns the rectangle of the screen covered by the view with the
+// given name, including its frame.
+func (g *Gui) viewRect(name string) (x0, y0, x1, y1 int, err error) {
+	v, err := g.View(name)
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+	x0, y0, x1, y1 = v.GetBounds()
+	return x0, y0, x1, y1, nil
+}
+
+// ExportHTML writes the last drawn screen to w as an HTML document,
+// preserving colors and attributes.
+func (g *Gui) ExportHTML(w io.Writer) error {
+	return writeHTML(w, g.exportRows(0, 0, g.maxX-1, g.maxY-1))
+}
+
+// ExportViewHTML writes the view with the given name, including its frame,
+// to w as an HTML document, preserving colors and attributes.
+func (g *Gui) ExportViewHTML(w io.Writer, name string) error {
+	x0, y0, x1, y1, err := g.viewRect(name)
+	if err != nil {
+		return err
+	}
+	return writeHTML(w, g.exportRows(x0, y0, x1, y1))
+}
+
+// ExportSVG writes the last drawn screen to w as an SVG document,
+// preserving colors and attributes.
+func (g *Gui) ExportSVG(w io.Writer) error {
+	return writeSVG(w, g.exportRows(0, 0, g.maxX-1, g.maxY-1))
+}
+
+// ExportViewSVG writes the view with the given name, including its frame,
+// to w as an SVG document, preserving colors and attributes.
+func (g *Gui) ExportViewSVG(w io.Writer, name string) error {
+	x0, y0, x1, y1, err := g.viewRect(name)
+	if err != nil {
+		return err
+	}
+	return writeSVG(w, g.exportRows(x0, y0, x1, y1))
+}
+
+// Dump writes the last drawn screen to w as text, where colors and attributes
+// are encoded as ANSI escape sequences. The output reproduces the look of the
+// screen when it is printed in a terminal.
+func (g *Gui) Dump(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	buf := termbox.CellBuffer()
+	for y := 0; y < g.maxY; y++ {
+		line := make(lineType, g.maxX)
+		for x := range line {
+			c := buf[y*g.maxX+x]
+			line[x] = cell{
+				chr:     c.Ch,
+				fgColor: Attribute(c.Fg),
+				bgColor: Attribute(c.Bg),
+			}
+		}
+		fmt.Fprintln(bw, line.ansi())
+	}
+
+	return bw.Flush()
+}
+
+// writeHTML writes the given rows as an HTML document.
+func writeHTML(w io.Writer, rows [][]exportRun) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintln(bw, "<!DOCTYPE html>")
+	fmt.Fprintln(bw, "<html>")
+	fmt.Fprintln(bw, "<head>")
+	fmt.Fprintln(bw, `<meta charset="utf-8">`)
+	fmt.Fprintln(bw, "<title>gocui</title>")
+	fmt.Fprintln(bw, "</head>")
+	fmt.Fprintln(bw, "<body>")
+	fmt.Fprintf(bw, `<pre style="font-family:monospace;line-height:1.2;color:%s;background-color:%s;display:inline-block">`,
+		exportFgColor, exportBgColor)
+	for i, runs := range rows {
+		if i > 0 {
+			fmt.Fprintln(bw)
+		}
+		for _, r := range runs {
+			fmt.Fprintf(bw, `<span style="%s">%s</span>`, r.style.css(), html.EscapeString(r.text))
+		}
+	}
+	fmt.Fprintln(bw, "</pre>")
+	fmt.Fprintln(bw, "</body>")
+	fmt.Fprintln(bw, "</html>")
+
+	return bw.Flush()
+}
+
+// writeSVG writes the given rows as an SVG document.
+func writeSVG(w io.Writer, rows [][]exportRun) error {
+	bw := bufio.NewWriter(w)
+
+	width := 0
+	if len(rows) > 0 {
+		for _, r := range rows[0] {
+			width += len([]rune(r.text))
+		}
+	}
+	width *= svgCellWidth
+	height := len(rows) * svgCellHeight
+
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		width, height, width, height)
+	fmt.Fprintf(bw, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", exportBgColor)
+	fmt.Fprintf(bw, `<g font-family="monospace" font-size="%d" xml:space="preserve">`+"\n", svgFontSize)
+	for y, runs := range rows {
+		for _, r := range runs {
+			n := len([]rune(r.text))
+			if r.style.bg != exportBgColor {
+				fmt.Fprintf(bw, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n",
+					r.x*svgCellWidth, y*svgCellHeight, n*svgCellWidth, svgCellHeight, r.style.bg)
+			}
+		}
+		for _, r := range runs {
+			dec := r.style.decoration()
+			if strings.TrimSpace(r.text) == "" && dec == "" {
+				continue
+			}
+			n := len([]rune(r.text))
+			attrs := fmt.Sprintf(`x="%d" y="%d" textLength="%d" lengthAdjust="spacingAndGlyphs" fill="%s"`,
+				r.x*svgCellWidth, y*svgCellHeight+svgBaseline, n*svgCellWidth, r.style.fg)
+			if r.style.bold {
+				attrs += ` font-weight="bold"`
+			}
+			if r.style.italic {
+				attrs += ` font-style="italic"`
+			}
+			if dec != "" {
+				attrs += ` text-decoration="` + dec + `"`
+			}
+			fmt.Fprintf(bw, "<text %s>%s</text>\n", attrs, html.EscapeString(r.text))
+		}
+	}
+	fmt.Fprintln(bw, "</g>")
+	fmt.Fprintln(bw, "</svg>")
+
+	return bw.Flush()
+}
@@ -0,0 +1,212 @@
+// Copyright 2014 The gocui Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gocui
+
+import "sort"
+
+// FocusPolicy determines which views get the focus when they are clicked.
+type FocusPolicy int
+
+// Focus policies.
+const (
+	// FocusNone does not change the focus on click.
+	FocusNone FocusPolicy = iota
+
+	// FocusAll gives the focus to any clicked view.
+	FocusAll
+
+	// FocusFocusable gives the focus to the clicked view only if it is
+	// focusable.
+	FocusFocusable
+)
+
+// SetFocusChangeHandler sets the function that is called every time the
+// current view changes. from is nil if no view had the focus.
+func (g *Gui) SetFocusChangeHandler(handler func(g Guier, from, to Viewer) error) {
+	g.focusChangeHandler = handler
+}
+
+// setFocus gives the focus to v. If the current view changes, the focus-out
+// handler of the previous view, the focus-in handler of v and the focus change
+// handler are called.
+func (g *Gui) setFocus(v Viewer) error {
+	from := g.currentView
+	if v == from {
+		return nil
+	}
+	if from != nil {
+		if err := from.FocusOut(g); err != nil {
+			return err
+		}
+	}
+	g.currentView = v
+	if v != nil {
+		if err := v.FocusIn(g); err != nil {
+			return err
+		}
+	}
+	if g.focusChangeHandler != nil {
+		return g.focusChangeHandler(g, from, v)
+	}
+	return nil
+}
+
+// focusOnClick gives the focus to a clicked view, following the focus policy
+// of the GUI.
+func (g *Gui) focusOnClick(v Viewer) error {
+	switch g.FocusOnClick {
+	case FocusAll:
+		return g.setFocus(v)
+	case FocusFocusable:
+		if v.IsFocusable() {
+			return g.setFocus(v)
+		}
+	}
+	return nil
+}
+
+// FocusNext gives the focus to the next view in the focus order, which
+// follows the TabIndex of the focusable views, and returns it. After the last
+// view, the focus goes back to the first one. It returns ErrUnknownView if
+// there are no views in the focus order.
+func (g *Gui) FocusNext() (Viewer, error) {
+	return g.moveFocus(1)
+}
+
+// FocusPrevious gives the focus to the previous view in the focus order. See
+// FocusNext.
+func (g *Gui) FocusPrevious() (Viewer, error) {
+	return g.moveFocus(-1)
+}
+
+// moveFocus gives the focus to the view at the distance d of the current one
+// in the focus order.
+func (g *Gui) moveFocus(d int) (Viewer, error) {
+	order := g.focusOrder()
+	if len(order) == 0 {
+		return nil, ErrUnknownView
+	}
+
+	i := -1
+	for j, v := range order {
+		if v == g.currentView {
+			i = j
+			break
+		}
+	}
+	switch {
+	case i >= 0:
+		i = (i + d + len(order)) % len(order)
+	case d > 0:
+		i = 0
+	default:
+		i = len(order) - 1
+	}
+
+	v := order[i]
+	return v, g.setFocus(v)
+}
+
+// focusOrder returns the focusable views with a non-negative TabIndex, sorted
+// by it.
+func (g *Gui) focusOrder() []Viewer {
+	var order []Viewer
+	for _, v := range g.views {
+		if v.IsFocusable() && v.GetTabIndex() >= 0 {
+			order = append(order, v)
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return order[i].GetTabIndex() < order[j].GetTabIndex()
+	})
+	return order
+}
+
+// FocusLeft gives the focus to the nearest focusable view to the left of the
+// current one and returns it. Views that overlap vertically with the current
+// one are preferred, like in tmux. If there is no view in that direction, the
+// focus does not change and the current view is returned. It returns
+// ErrUnknownView if no view has the focus.
+func (g *Gui) FocusLeft() (Viewer, error) {
+	return g.moveFocusSpatial(-1, 0)
+}
+
+// FocusRight gives the focus to the nearest focusable view to the right of
+// the current one. See FocusLeft.
+func (g *Gui) FocusRight() (Viewer, error) {
+	return g.moveFocusSpatial(1, 0)
+}
+
+// FocusUp gives the focus to the nearest focusable view above the current
+// one. See FocusLeft.
+func (g *Gui) FocusUp() (Viewer, error) {
+	return g.moveFocusSpatial(0, -1)
+}
+
+// FocusDown gives the focus to the nearest focusable view below the current
+// one. See FocusLeft.
+func (g *Gui) FocusDown() (Viewer, error) {
+	return g.moveFocusSpatial(0, 1)
+}
+
+// moveFocusSpatial gives the focus to the nearest view in the direction
+// (dx, dy), where only one of them is not 0.
+func (g *Gui) moveFocusSpatial(dx, dy int) (Viewer, error) {
+	cur := g.currentView
+	if cur == nil {
+		return nil, ErrUnknownView
+	}
+
+	// bounds are transposed for vertical movements, so the candidates are
+	// always searched along the x axis, in the direction dir
+	dir := dx + dy
+	bounds := func(v Viewer) (a0, b0, a1, b1 int) {
+		x0, y0, x1, y1 := v.GetBounds()
+		if dy != 0 {
+			return y0, x0, y1, x1
+		}
+		return x0, y0, x1, y1
+	}
+	ca0, cb0, ca1, cb1 := bounds(cur)
+
+	var (
+		best                  Viewer
+		bestGap, bestDistance int
+		bestOverlap           bool
+	)
+	for _, v := range g.views {
+		if v == cur || !v.IsFocusable() {
+			continue
+		}
+		a0, b0, a1, b1 := bounds(v)
+
+		// gap between the views along the direction of the movement
+		gap := a0 - ca1
+		if dir < 0 {
+			gap = ca0 - a1
+		}
+		if gap < 0 {
+			continue
+		}
+
+		// distance between the centers in the perpendicular axis
+		overlap := b0 < cb1 && b1 > cb0
+		distance := (b0 + b1) - (cb0 + cb1)
+		if distance < 0 {
+			distance = -distance
+		}
+
+		if best == nil ||
+			(overlap && !bestOverlap) ||
+			(overlap == bestOverlap && (gap < bestGap || (gap == bestGap && distance < bestDistance))) {
+			best, bestGap, bestDistance, bestOverlap = v, gap, distance, overlap
+		}
+	}
+
+	if best == nil {
+		return cur, nil
+	}
+	return best, g.setFocus(best)
+}
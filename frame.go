@@ -0,0 +1,137 @@
+// Copyright 2014 The gocui Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gocui
+
+// Edges of the frame of a view that are being dragged.
+const (
+	edgeLeft = 1 << iota
+	edgeTop
+	edgeRight
+	edgeBottom
+	edgeAll = edgeLeft | edgeTop | edgeRight | edgeBottom
+)
+
+// frameDrag describes a view that is being resized or moved with the mouse.
+type frameDrag struct {
+	v              Viewer
+	edges          int // edges that follow the mouse, edgeAll if moving
+	x0, y0, x1, y1 int // bounds of the view when the drag started
+}
+
+// SetViewBoundsHandler sets the function that is called every time the user
+// resizes or moves a view with the mouse. Given that the Layout function of
+// the managers sets the bounds of the views on every iteration, the handler
+// should store the new bounds so Layout can use them.
+func (g *Gui) SetViewBoundsHandler(handler func(g Guier, name string, x0, y0, x1, y1 int) error) {
+	g.viewBoundsHandler = handler
+}
+
+// frameAt returns the view whose frame can be dragged at the given position,
+// and the edges that would be dragged. The top edge moves the view if it is
+// movable, while the rest of edges and the corners resize it.
+func (g *Gui) frameAt(x, y int) (Viewer, int) {
+	// traverse views in reverse order checking top views first
+	for i := len(g.views); i > 0; i-- {
+		v := g.views[i-1]
+		x0, y0, x1, y1 := v.GetBounds()
+		if x < x0 || x > x1 || y < y0 || y > y1 {
+			continue
+		}
+		if !v.HasFrame() || (x > x0 && x < x1 && y > y0 && y < y1) {
+			return nil, 0
+		}
+
+		if v.IsMovable() && y == y0 && x > x0 && x < x1 {
+			return v, edgeAll
+		}
+		if !v.IsResizable() {
+			return nil, 0
+		}
+		edges := 0
+		if x == x0 {
+			edges |= edgeLeft
+		}
+		if y == y0 {
+			edges |= edgeTop
+		}
+		if x == x1 {
+			edges |= edgeRight
+		}
+		if y == y1 {
+			edges |= edgeBottom
+		}
+		return v, edges
+	}
+	return nil, 0
+}
+
+// startFrameDrag starts resizing or moving a view if the mouse button has
+// been pressed on its frame. It returns false if there is no such view.
+func (g *Gui) startFrameDrag(e *Event) bool {
+	v, edges := g.frameAt(e.MouseX, e.MouseY)
+	if v == nil {
+		return false
+	}
+	fd := &frameDrag{v: v, edges: edges}
+	fd.x0, fd.y0, fd.x1, fd.y1 = v.GetBounds()
+	g.frameDrag = fd
+	return true
+}
+
+// dragFrame updates the bounds of the view being resized or moved, following
+// the mouse, and reports them to the view bounds handler. The drag ends if
+// the view has been deleted.
+func (g *Gui) dragFrame(e *Event) error {
+	fd := g.frameDrag
+	if v, err := g.View(fd.v.Name()); err != nil || v != fd.v {
+		g.endDrag()
+		return nil
+	}
+	dx, dy := e.MouseX-e.DragX, e.MouseY-e.DragY
+	x0, y0, x1, y1 := fd.x0, fd.y0, fd.x1, fd.y1
+
+	if fd.edges == edgeAll {
+		x0, y0, x1, y1 = x0+dx, y0+dy, x1+dx, y1+dy
+	} else {
+		if fd.edges&edgeLeft != 0 {
+			x0 += dx
+		}
+		if fd.edges&edgeTop != 0 {
+			y0 += dy
+		}
+		if fd.edges&edgeRight != 0 {
+			x1 += dx
+		}
+		if fd.edges&edgeBottom != 0 {
+			y1 += dy
+		}
+		// the view must keep at least one cell inside its frame
+		if x1-x0 < 2 {
+			if fd.edges&edgeLeft != 0 {
+				x0 = x1 - 2
+			} else {
+				x1 = x0 + 2
+			}
+		}
+		if y1-y0 < 2 {
+			if fd.edges&edgeTop != 0 {
+				y0 = y1 - 2
+			} else {
+				y1 = y0 + 2
+			}
+		}
+	}
+
+	if cx0, cy0, cx1, cy1 := fd.v.GetBounds(); cx0 == x0 && cy0 == y0 && cx1 == x1 && cy1 == y1 {
+		return nil
+	}
+	if _, err := g.SetView(fd.v.Name(), x0, y0, x1, y1); err != nil {
+		return err
+	}
+	if g.viewBoundsHandler != nil {
+		return g.viewBoundsHandler(g, fd.v.Name(), x0, y0, x1, y1)
+	}
+	return nil
+}
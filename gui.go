@@ -6,6 +6,9 @@ package gocui
 
 import (
 	"errors"
+	"io"
+	"os"
+	"time"
 
 	"github.com/thermeon/termbox-go"
 )
@@ -16,6 +19,11 @@ var (
 
 	// ErrUnknownView allows to assert if a View must be initialized.
 	ErrUnknownView = errors.New("unknown view")
+
+	// ErrPropagate can be returned by keybinding handlers to let the event
+	// reach the keybindings with lower precedence and, finally, the editor of
+	// the current view.
+	ErrPropagate = errors.New("propagate")
 )
 
 // OutputMode represents the terminal's output mode (8 or 256 colors).
@@ -41,6 +49,37 @@ type Gui struct {
 	maxX, maxY  int
 	outputMode  OutputMode
 
+	pendingKeys        []KeyPress // key-presses of an incomplete sequence
+	pendingID          int        // identifies pendingKeys for timeouts
+	pendingKeysHandler func(Guier, []KeyPress) error
+
+	mode              string // current input mode
+	modeChangeHandler func(g Guier, from, to string) error
+
+	lastClick     *Event // used to count consecutive clicks
+	mouseDown     *Event // last mouse button press, where drags start
+	dragView      Viewer // view where the drag started
+	dragging      bool
+	frameDrag     *frameDrag // view being resized or moved
+	motionEnabled bool       // mouse motion tracking enabled in the terminal
+
+	viewBoundsHandler  func(g Guier, name string, x0, y0, x1, y1 int) error
+	focusChangeHandler func(g Guier, from, to Viewer) error
+
+	execKeys     []KeyPress // key-presses of the keybinding being executed
+	recording    string     // register of the macro being recorded
+	recordedKeys []KeyPress
+	replaying    bool
+	macros       map[string][]KeyPress
+
+	pasteEnabled  bool            // bracketed paste mode enabled in the terminal
+	pasting       bool            // between the start and end paste markers
+	pasteText     string          // text pasted so far
+	pasteMarker   string          // beginning of a paste marker
+	pasteHeld     []termbox.Event // key-presses of pasteMarker
+	pasteTimer    *time.Timer     // flushes pasteMarker when it expires
+	pasteDeadline time.Time       // when pasteMarker must be flushed
+
 	// BgColor and FgColor allow to configure the background and foreground
 	// colors of the GUI.
 	BgColor, FgColor Attribute
@@ -59,6 +98,15 @@ type Gui struct {
 	// If Mouse is true then mouse events will be enabled.
 	Mouse bool
 
+	// If MouseMotion is true, and Mouse is enabled, the terminal reports the
+	// motion of the mouse even if no button is pressed, which is needed for
+	// hover events. Drags do not need it. It can be changed at any time.
+	MouseMotion bool
+
+	// FocusOnClick determines which views get the focus when they are
+	// clicked. The default policy, FocusNone, does not change the focus.
+	FocusOnClick FocusPolicy
+
 	// If InputEsc is true, when ESC sequence is in the buffer and it doesn't
 	// match any known sequence, ESC means KeyEsc.
 	InputEsc bool
@@ -66,6 +114,23 @@ type Gui struct {
 	// If ASCII is true then use ASCII instead of unicode to draw the
 	// interface. Using ASCII is more portable.
 	ASCII bool
+
+	// If BracketedPaste is true then the terminal is asked to mark the text
+	// pasted by the user, which is inserted at once in the current view
+	// instead of being handled as key-presses, so it does not trigger
+	// keybindings. It is disabled by default, and can be changed at any time.
+	BracketedPaste bool
+
+	// ClipboardCommand is an optional command, with its arguments, that
+	// receives the text copied by CopyToClipboard in its standard input, for
+	// terminals that do not support OSC 52. It is run on every copy. E.g.
+	// {"xclip", "-selection", "clipboard"}.
+	ClipboardCommand []string
+
+	// KeySequenceTimeout is the time to wait for the next key-press of a
+	// sequence. When it expires, the keys pressed so far are handled as if
+	// no more keys were expected. If it is 0, there is no timeout.
+	KeySequenceTimeout time.Duration
 }
 
 func (g *Gui) GetBgFgColor() (BgColor, FgColor Attribute) {
@@ -110,6 +175,14 @@ func (g *Gui) SetMouseEventsEnabled(e bool) {
 	g.Mouse = e
 }
 
+func (g *Gui) GetMouseMotion() bool {
+	return g.MouseMotion
+}
+
+func (g *Gui) SetMouseMotion(m bool) {
+	g.MouseMotion = m
+}
+
 func (g *Gui) GetInputEsc() bool {
 	return g.InputEsc
 }
@@ -126,6 +199,38 @@ func (g *Gui) SetASCII(a bool) {
 	g.ASCII = a
 }
 
+func (g *Gui) GetBracketedPaste() bool {
+	return g.BracketedPaste
+}
+
+func (g *Gui) SetBracketedPaste(b bool) {
+	g.BracketedPaste = b
+}
+
+func (g *Gui) GetFocusOnClick() FocusPolicy {
+	return g.FocusOnClick
+}
+
+func (g *Gui) SetFocusOnClick(p FocusPolicy) {
+	g.FocusOnClick = p
+}
+
+func (g *Gui) GetClipboardCommand() []string {
+	return g.ClipboardCommand
+}
+
+func (g *Gui) SetClipboardCommand(cmd []string) {
+	g.ClipboardCommand = cmd
+}
+
+func (g *Gui) GetKeySequenceTimeout() time.Duration {
+	return g.KeySequenceTimeout
+}
+
+func (g *Gui) SetKeySequenceTimeout(d time.Duration) {
+	g.KeySequenceTimeout = d
+}
+
 // NewGui returns a new Gui object with a given output mode.
 func NewGui(mode OutputMode) (Guier, error) {
 	if err := termbox.Init(); err != nil {
@@ -145,15 +250,40 @@ func NewGui(mode OutputMode) (Guier, error) {
 	g.BgColor, g.FgColor = ColorDefault, ColorDefault
 	g.SelBgColor, g.SelFgColor = ColorDefault, ColorDefault
 
+	g.KeySequenceTimeout = time.Second
+
 	return g, nil
 }
 
 // Close finalizes the library. It should be called after a successful
 // initialization and when gocui is not needed anymore.
 func (g *Gui) Close() {
+	g.enableBracketedPaste(false)
+	g.enableMouseMotion(false)
 	termbox.Close()
 }
 
+// terminalOutput is where the escape sequences that termbox does not know
+// about are written. It is opened on first use.
+var terminalOutput io.Writer
+
+// writeTerminal writes an escape sequence directly to the terminal, to
+// enable features termbox does not know about. Like termbox, it writes to
+// /dev/tty, so it works even if the standard output is redirected, falling
+// back to the standard output if /dev/tty cannot be opened.
+func writeTerminal(seq string) error {
+	if terminalOutput == nil {
+		tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+		if err != nil {
+			terminalOutput = os.Stdout
+		} else {
+			terminalOutput = tty
+		}
+	}
+	_, err := io.WriteString(terminalOutput, seq)
+	return err
+}
+
 // Size returns the terminal's size.
 func (g *Gui) Size() (x, y int) {
 	return g.maxX, g.maxY
@@ -278,18 +408,21 @@ func (g *Gui) DeleteView(name string) error {
 	for i, v := range g.views {
 		if v.Name() == name {
 			g.views = append(g.views[:i], g.views[i+1:]...)
+			if v == g.dragView || (g.frameDrag != nil && v == g.frameDrag.v) {
+				g.endDrag()
+			}
 			return nil
 		}
 	}
 	return ErrUnknownView
 }
 
-// SetCurrentView gives the focus to a given view.
+// SetCurrentView gives the focus to a given view, calling the focus change
+// handler if the current view changes.
 func (g *Gui) SetCurrentView(name string) (Viewer, error) {
 	for _, v := range g.views {
 		if v.Name() == name {
-			g.currentView = v
-			return v, nil
+			return v, g.setFocus(v)
 		}
 	}
 	return nil, ErrUnknownView
@@ -303,28 +436,100 @@ func (g *Gui) CurrentView() Viewer {
 
 // SetKeybinding creates a new keybinding. If viewname equals to ""
 // (empty string) then the keybinding will apply to all views. key must
-// be a rune or a Key.
+// be a rune, a Key, a human-readable key specification like "ctrl+s" or
+// "g g" (see ParseKeySequence), whose modifiers are combined with mod, or a
+// KeyPredicate like AnyRune.
+//
+// Keybindings of a view take precedence over the global ones, exact
+// keybindings take precedence over the ones of predicates, and the first
+// matching keybinding handles the event unless its handler returns
+// ErrPropagate. Global keybindings of runes without modifiers are ignored
+// when the current view is editable, so the runes reach its editor.
 func (g *Gui) SetKeybinding(viewname string, key interface{}, mod Modifier, handler func(Guier, Viewer) error) error {
-	var kb *keybinding
+	keys, pred, err := getKeys(key, mod)
+	if err != nil {
+		return err
+	}
+	return g.setKeybinding("", viewname, keys, pred, eventHandler(handler))
+}
 
-	k, ch, err := getKey(key)
+// SetEventKeybinding creates a new keybinding whose handler receives the Event
+// that triggered it, which includes the position of the mouse. Its arguments
+// are handled like the ones of SetKeybinding.
+func (g *Gui) SetEventKeybinding(viewname string, key interface{}, mod Modifier, handler func(Guier, Viewer, *Event) error) error {
+	keys, pred, err := getKeys(key, mod)
 	if err != nil {
 		return err
 	}
-	kb = newKeybinding(viewname, k, ch, mod, handler)
-	g.keybindings = append(g.keybindings, kb)
-	return nil
+	return g.setKeybinding("", viewname, keys, pred, handler)
 }
 
 // DeleteKeybinding deletes a keybinding.
 func (g *Gui) DeleteKeybinding(viewname string, key interface{}, mod Modifier) error {
-	k, ch, err := getKey(key)
+	keys, pred, err := getKeys(key, mod)
+	if err != nil {
+		return err
+	}
+	return g.deleteKeybinding("", viewname, keys, pred)
+}
+
+// SetKeySequence creates a new keybinding that is triggered by a sequence of
+// key-presses, like "g g" or "Ctrl-X Ctrl-S". If viewname equals to ""
+// (empty string) then the keybinding will apply to all views.
+func (g *Gui) SetKeySequence(viewname string, keys []KeyPress, handler func(Guier, Viewer) error) error {
+	return g.setKeybinding("", viewname, keys, nil, eventHandler(handler))
+}
+
+// DeleteKeySequence deletes a keybinding triggered by a sequence of
+// key-presses.
+func (g *Gui) DeleteKeySequence(viewname string, keys []KeyPress) error {
+	return g.deleteKeybinding("", viewname, keys, nil)
+}
+
+// SetModeKeybinding creates a new keybinding that is only active when the
+// given input mode is the current one. Its arguments are handled like the
+// ones of SetKeybinding. Keybindings of the current mode take precedence
+// over the keybindings that apply to all modes.
+func (g *Gui) SetModeKeybinding(mode, viewname string, key interface{}, mod Modifier, handler func(Guier, Viewer) error) error {
+	if mode == "" {
+		return errors.New("invalid mode")
+	}
+	keys, pred, err := getKeys(key, mod)
+	if err != nil {
+		return err
+	}
+	return g.setKeybinding(mode, viewname, keys, pred, eventHandler(handler))
+}
+
+// DeleteModeKeybinding deletes a keybinding of the given input mode.
+func (g *Gui) DeleteModeKeybinding(mode, viewname string, key interface{}, mod Modifier) error {
+	keys, pred, err := getKeys(key, mod)
 	if err != nil {
 		return err
 	}
+	return g.deleteKeybinding(mode, viewname, keys, pred)
+}
 
+// setKeybinding creates a new keybinding for the given mode, view and
+// sequence of key-presses or predicate.
+func (g *Gui) setKeybinding(mode, viewname string, keys []KeyPress, pred *KeyPredicate, handler func(Guier, Viewer, *Event) error) error {
+	if len(keys) == 0 {
+		return errors.New("empty key sequence")
+	}
+	s := make([]KeyPress, len(keys))
+	copy(s, keys)
+	kb := newKeybinding(viewname, s, handler)
+	kb.predicate = pred
+	kb.mode = mode
+	g.keybindings = append(g.keybindings, kb)
+	return nil
+}
+
+// deleteKeybinding deletes the keybinding for the given mode, view and
+// sequence of key-presses or predicate.
+func (g *Gui) deleteKeybinding(mode, viewname string, keys []KeyPress, pred *KeyPredicate) error {
 	for i, kb := range g.keybindings {
-		if kb.viewName == viewname && kb.ch == ch && kb.key == k && kb.mod == mod {
+		if kb.mode == mode && kb.viewName == viewname && kb.sameKeys(keys, pred) {
 			g.keybindings = append(g.keybindings[:i], g.keybindings[i+1:]...)
 			return nil
 		}
@@ -332,6 +537,97 @@ func (g *Gui) DeleteKeybinding(viewname string, key interface{}, mod Modifier) e
 	return errors.New("keybinding not found")
 }
 
+// Mode returns the current input mode. The default mode is "" (empty
+// string).
+func (g *Gui) Mode() string {
+	return g.mode
+}
+
+// SetMode changes the current input mode, which determines the active
+// keybindings, and calls the mode change handler. The key-presses of an
+// incomplete sequence are discarded.
+func (g *Gui) SetMode(mode string) error {
+	if mode == g.mode {
+		return nil
+	}
+	if len(g.pendingKeys) > 0 {
+		if err := g.setPendingKeys(nil); err != nil {
+			return err
+		}
+	}
+
+	from := g.mode
+	g.mode = mode
+	if g.modeChangeHandler != nil {
+		return g.modeChangeHandler(g, from, mode)
+	}
+	return nil
+}
+
+// SetModeChangeHandler sets the function that is called every time the
+// input mode changes, for instance to update a status bar.
+func (g *Gui) SetModeChangeHandler(handler func(g Guier, from, to string) error) {
+	g.modeChangeHandler = handler
+}
+
+// SetKeybindingDescription sets the description of a keybinding, which is
+// shown by ShowHelp. If there are several keybindings for the key in
+// different modes, the most recent one is described.
+func (g *Gui) SetKeybindingDescription(viewname string, key interface{}, mod Modifier, description string) error {
+	keys, pred, err := getKeys(key, mod)
+	if err != nil {
+		return err
+	}
+	// the most recent keybinding is the one that has been just created
+	for i := len(g.keybindings) - 1; i >= 0; i-- {
+		if kb := g.keybindings[i]; kb.viewName == viewname && kb.sameKeys(keys, pred) {
+			kb.description = description
+			return nil
+		}
+	}
+	return errors.New("keybinding not found")
+}
+
+// Keybindings returns the description of all the keybindings, in the order
+// they were created.
+func (g *Gui) Keybindings() []KeybindingInfo {
+	var kbs []KeybindingInfo
+	for _, kb := range g.keybindings {
+		if kb.handler == nil {
+			continue
+		}
+		info := KeybindingInfo{
+			View:        kb.viewName,
+			Mode:        kb.mode,
+			Description: kb.description,
+		}
+		if kb.predicate != nil {
+			info.Predicate = kb.predicate.Name
+			if kb.keys[0].Mod&ModAlt != 0 {
+				info.Predicate = "alt+" + info.Predicate
+			}
+		} else {
+			info.Keys = make([]KeyPress, len(kb.keys))
+			copy(info.Keys, kb.keys)
+		}
+		kbs = append(kbs, info)
+	}
+	return kbs
+}
+
+// SetPendingKeysHandler sets the function that is called every time the
+// key-presses of an incomplete sequence change, so they can be shown to the
+// user. It is called with an empty slice when the sequence is completed,
+// cancelled or timed out.
+func (g *Gui) SetPendingKeysHandler(handler func(Guier, []KeyPress) error) {
+	g.pendingKeysHandler = handler
+}
+
+// PendingKeys returns the key-presses of the sequence that is being typed.
+func (g *Gui) PendingKeys() []KeyPress {
+	return g.pendingKeys
+}
+
 // DeleteKeybindings deletes all keybindings of view.
 func (g *Gui) DeleteKeybindings(viewname string) {
 	var s []*keybinding
@@ -343,16 +639,32 @@ func (g *Gui) DeleteKeybindings(viewname string) {
 	g.keybindings = s
 }
 
-// getKey takes an empty interface with a key and returns the corresponding
-// typed Key or rune.
-func getKey(key interface{}) (Key, rune, error) {
+// getKeys takes an empty interface with a key and a modifier, and returns
+// the corresponding sequence of key-presses. key can be a typed Key, a rune,
+// a key specification or a KeyPredicate. In the last case, the predicate is
+// returned along with a single key-press that only holds the modifier.
+func getKeys(key interface{}, mod Modifier) ([]KeyPress, *KeyPredicate, error) {
 	switch t := key.(type) {
 	case Key:
-		return t, 0, nil
+		return []KeyPress{{Key: t, Mod: mod}}, nil, nil
 	case rune:
-		return 0, t, nil
+		return []KeyPress{{Ch: t, Mod: mod}}, nil, nil
+	case string:
+		keys, err := ParseKeySequence(t)
+		if err != nil {
+			return nil, nil, err
+		}
+		for i := range keys {
+			keys[i].Mod |= mod
+		}
+		return keys, nil, nil
+	case KeyPredicate:
+		if t.Match == nil {
+			return nil, nil, errors.New("invalid key predicate")
+		}
+		return []KeyPress{{Mod: mod}}, &t, nil
 	default:
-		return 0, 0, errors.New("unknown type")
+		return nil, nil, errors.New("unknown type")
 	}
 }
 
@@ -394,6 +706,8 @@ func (g *Gui) SetManager(managers ...Manager) {
 	g.currentView = nil
 	g.views = nil
 	g.keybindings = nil
+	g.pendingKeys = nil
+	g.endDrag()
 
 	go func() { g.tbEvents <- termbox.Event{Type: termbox.EventResize} }()
 }
@@ -422,6 +736,9 @@ func (g *Gui) MainLoop() error {
 	}
 	termbox.SetInputMode(inputMode)
 
+	if err := g.setTerminalModes(); err != nil {
+		return err
+	}
 	if err := g.flush(); err != nil {
 		return err
 	}
@@ -439,12 +756,29 @@ func (g *Gui) MainLoop() error {
 		if err := g.consumeevents(); err != nil {
 			return err
 		}
+		if err := g.setTerminalModes(); err != nil {
+			return err
+		}
 		if err := g.flush(); err != nil {
 			return err
 		}
 	}
 }
 
+// setTerminalModes enables or disables the terminal features that termbox
+// does not know about, following the options of the Gui, which can be changed
+// by the handlers.
+func (g *Gui) setTerminalModes() error {
+	if !g.BracketedPaste && g.pasteEnabled {
+		if err := g.finishPaste(); err != nil {
+			return err
+		}
+	}
+	g.enableBracketedPaste(g.BracketedPaste)
+	g.enableMouseMotion(g.Mouse && g.MouseMotion)
+	return nil
+}
+
 // consumeevents handles the remaining events in the events pool.
 func (g *Gui) consumeevents() error {
 	for {
@@ -467,7 +801,12 @@ func (g *Gui) consumeevents() error {
 // etc.)
 func (g *Gui) handleEvent(ev *termbox.Event) error {
 	switch ev.Type {
-	case termbox.EventKey, termbox.EventMouse:
+	case termbox.EventKey:
+		if g.pasteEnabled {
+			return g.onPasteKey(ev)
+		}
+		return g.onKey(ev)
+	case termbox.EventMouse:
 		return g.onKey(ev)
 	case termbox.EventError:
 		return ev.Err
@@ -657,50 +996,201 @@ func (g *Gui) draw(v Viewer) error {
 // a key-press or mouse event satisfies a configured keybinding. Furthermore,
 // currentView's internal buffer is modified if currentView.Editable is true.
 func (g *Gui) onKey(ev *termbox.Event) error {
+	e := newEvent(ev)
+
 	switch ev.Type {
 	case termbox.EventKey:
-		matched, err := g.execKeybindings(g.currentView, ev)
-		if err != nil {
+		recording := g.recording != ""
+		if err := g.onKeyPress(e); err != nil {
 			return err
 		}
-		if matched {
-			break
-		}
-		if g.currentView != nil && g.currentView.IsEditable() && g.currentView.GetEditor() != nil {
-			g.currentView.GetEditor().Edit(g.currentView, Key(ev.Key), ev.Ch, Modifier(ev.Mod))
+		if recording && g.recording != "" {
+			g.recordedKeys = append(g.recordedKeys, e.keyPress())
 		}
 	case termbox.EventMouse:
-		mx, my := ev.MouseX, ev.MouseY
-		v, err := g.ViewByPosition(mx, my)
-		if err != nil {
-			break
+		return g.onMouse(e)
+	}
+
+	return nil
+}
+
+// onKeyPress handles a key-press event, taking into account the key-presses
+// of the sequence that is being typed.
+func (g *Gui) onKeyPress(e *Event) error {
+	kp := e.keyPress()
+	pending := g.pendingKeys
+	keys := make([]KeyPress, len(pending)+1)
+	copy(keys, pending)
+	keys[len(pending)] = kp
+
+	match, prefix := g.matchSequence(g.currentView, keys)
+	switch {
+	case prefix:
+		return g.setPendingKeys(keys)
+	case match:
+		if len(pending) > 0 {
+			if err := g.setPendingKeys(nil); err != nil {
+				return err
+			}
 		}
-		x0, _, y0, _ := v.GetBounds()
-		if err := v.SetCursor(mx-x0-1, my-y0-1); err != nil {
+		matched, err := g.execKeybindings(g.currentView, keys, e)
+		if !matched && err == nil {
+			// every handler returned ErrPropagate
+			for _, k := range keys {
+				g.edit(k)
+			}
+		}
+		return err
+	case len(pending) > 0:
+		// kp does not continue any sequence: resolve what has been typed
+		// so far and handle kp on its own.
+		if err := g.resolvePendingKeys(); err != nil {
 			return err
 		}
-		if _, err := g.execKeybindings(v, ev); err != nil {
+		return g.onKeyPress(e)
+	default:
+		g.edit(kp)
+		return nil
+	}
+}
+
+// matchSequence returns if there is a keybinding for the given view that
+// matches the sequence of key-presses, and if keys is the beginning of a
+// longer sequence bound for the view.
+func (g *Gui) matchSequence(v Viewer, keys []KeyPress) (match, prefix bool) {
+	for _, kb := range g.keybindings {
+		if !g.activeKeybinding(kb, v, keys) {
+			continue
+		}
+		m, p := kb.matchSequence(keys)
+		match = match || m
+		prefix = prefix || p
+	}
+	return match, prefix
+}
+
+// setPendingKeys updates the key-presses of the sequence that is being typed,
+// restarting the timeout and notifying the pending keys handler.
+func (g *Gui) setPendingKeys(keys []KeyPress) error {
+	g.pendingKeys = keys
+	g.pendingID++
+
+	if len(keys) > 0 && g.KeySequenceTimeout > 0 {
+		id := g.pendingID
+		time.AfterFunc(g.KeySequenceTimeout, func() {
+			g.userEvents <- userEvent{f: func(Guier) error {
+				if id != g.pendingID {
+					return nil
+				}
+				return g.resolvePendingKeys()
+			}}
+		})
+	}
+
+	if g.pendingKeysHandler != nil {
+		return g.pendingKeysHandler(g, keys)
+	}
+	return nil
+}
+
+// resolvePendingKeys handles the key-presses of a sequence that cannot be
+// continued. The longest prefix bound to a keybinding is executed, or the
+// first key-press is passed to the editor if there is none, and the rest of
+// key-presses are handled again.
+func (g *Gui) resolvePendingKeys() error {
+	keys := g.pendingKeys
+	if err := g.setPendingKeys(nil); err != nil {
+		return err
+	}
+
+	n := len(keys)
+	for ; n > 0; n-- {
+		matched, err := g.execKeybindings(g.currentView, keys[:n], newKeyEvent(keys[n-1]))
+		if err != nil {
 			return err
 		}
+		if matched {
+			break
+		}
+	}
+	if n == 0 && len(keys) > 0 {
+		g.edit(keys[0])
+		n = 1
 	}
 
+	for _, kp := range keys[n:] {
+		if err := g.onKeyPress(newKeyEvent(kp)); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// edit passes a key-press to the editor of the current view, if it is
+// editable.
+func (g *Gui) edit(kp KeyPress) {
+	if g.currentView != nil && g.currentView.IsEditable() && g.currentView.GetEditor() != nil {
+		g.currentView.GetEditor().Edit(g.currentView, kp.Key, kp.Ch, kp.Mod)
+	}
+}
+
+// activeKeybinding returns if kb can be triggered in the given view by the
+// sequence of key-presses keys.
+func (g *Gui) activeKeybinding(kb *keybinding, v Viewer, keys []KeyPress) bool {
+	if kb.handler == nil || !kb.matchView(v) || !kb.matchMode(g.mode) {
+		return false
+	}
+	if kb.viewName == "" && v != nil && v == g.currentView && v.IsEditable() {
+		return len(keys) == 0 || !keys[0].isRune()
+	}
+	return true
+}
+
+// keybindingPrecedence returns the precedence level of a keybinding, lower
+// levels first: keybindings of the view before global ones, keybindings of
+// the current mode before the ones of all modes and exact keybindings before
+// the ones of predicates.
+func keybindingPrecedence(kb *keybinding) int {
+	level := 0
+	if kb.viewName == "" {
+		level += 4
+	}
+	if kb.mode == "" {
+		level += 2
+	}
+	if kb.predicate != nil {
+		level++
+	}
+	return level
+}
+
 // execKeybindings executes the keybinding handlers that match the passed view
-// and event. The value of matched is true if there is a match and no errors.
-func (g *Gui) execKeybindings(v Viewer, ev *termbox.Event) (matched bool, err error) {
-	matched = false
-	for _, kb := range g.keybindings {
-		if kb.handler == nil {
-			continue
-		}
-		if kb.matchKeypress(Key(ev.Key), ev.Ch, Modifier(ev.Mod)) && kb.matchView(v) {
-			if err := kb.handler(g, v); err != nil {
+// and sequence of key-presses, triggered by the event e, following their
+// precedence (see keybindingPrecedence). The first handler that does not
+// return ErrPropagate stops the propagation. The value of matched is true if
+// the event was handled and there were no errors.
+func (g *Gui) execKeybindings(v Viewer, keys []KeyPress, e *Event) (matched bool, err error) {
+	e.Keys = keys
+	for level := 0; level < 8; level++ {
+		for _, kb := range g.keybindings {
+			if keybindingPrecedence(kb) != level || !g.activeKeybinding(kb, v, keys) {
+				continue
+			}
+			if match, _ := kb.matchSequence(keys); !match {
+				continue
+			}
+			g.execKeys = keys
+			err := kb.handler(g, v, e)
+			g.execKeys = nil
+			switch err {
+			case nil:
+				return true, nil
+			case ErrPropagate:
+				continue
+			default:
 				return false, err
 			}
-			matched = true
 		}
 	}
-	return matched, nil
+	return false, nil
 }
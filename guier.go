@@ -4,6 +4,11 @@
 
 package gocui
 
+import (
+	"io"
+	"time"
+)
+
 // Gui represents the whole User Interface, including the views, layouts
 // and keybindings.
 type Guier interface {
@@ -22,9 +27,29 @@ type Guier interface {
 	SetCurrentView(name string) (Viewer, error)
 	CurrentView() Viewer
 	SetKeybinding(viewname string, key interface{}, mod Modifier, handler func(Guier, Viewer) error) error
+	SetEventKeybinding(viewname string, key interface{}, mod Modifier, handler func(Guier, Viewer, *Event) error) error
 	DeleteKeybinding(viewname string, key interface{}, mod Modifier) error
 	DeleteKeybindings(viewname string)
+	SetModeKeybinding(mode, viewname string, key interface{}, mod Modifier, handler func(Guier, Viewer) error) error
+	DeleteModeKeybinding(mode, viewname string, key interface{}, mod Modifier) error
+	Mode() string
+	SetMode(mode string) error
+	SetModeChangeHandler(handler func(g Guier, from, to string) error)
+	SetViewBoundsHandler(handler func(g Guier, name string, x0, y0, x1, y1 int) error)
+	SetKeybindingDescription(viewname string, key interface{}, mod Modifier, description string) error
+	Keybindings() []KeybindingInfo
+	ShowHelp(name string) (Viewer, error)
+	SetKeySequence(viewname string, keys []KeyPress, handler func(Guier, Viewer) error) error
+	DeleteKeySequence(viewname string, keys []KeyPress) error
+	SetPendingKeysHandler(handler func(Guier, []KeyPress) error)
+	PendingKeys() []KeyPress
 	Update(f func(Guier) error)
+	StartRecording(register string) error
+	StopRecording() error
+	Recording() string
+	Macro(register string) []KeyPress
+	SetMacro(register string, keys []KeyPress)
+	ReplayMacro(register string, times int) error
 	SetManager(managers ...Manager)
 	SetManagerFunc(manager func(Guier) error)
 	MainLoop() error
@@ -38,8 +63,31 @@ type Guier interface {
 	SetCursor(c bool)
 	GetMouseEventsEnabled() bool
 	SetMouseEventsEnabled(e bool)
+	GetMouseMotion() bool
+	SetMouseMotion(m bool)
 	GetInputEsc() bool
 	SetInputEsc(e bool)
+	Dump(w io.Writer) error
+	ExportHTML(w io.Writer) error
+	ExportViewHTML(w io.Writer, name string) error
+	ExportSVG(w io.Writer) error
+	ExportViewSVG(w io.Writer, name string) error
 	GetASCII() bool
 	SetASCII(a bool)
+	GetBracketedPaste() bool
+	SetBracketedPaste(b bool)
+	GetFocusOnClick() FocusPolicy
+	SetFocusOnClick(p FocusPolicy)
+	SetFocusChangeHandler(handler func(g Guier, from, to Viewer) error)
+	FocusNext() (Viewer, error)
+	FocusPrevious() (Viewer, error)
+	FocusLeft() (Viewer, error)
+	FocusRight() (Viewer, error)
+	FocusUp() (Viewer, error)
+	FocusDown() (Viewer, error)
+	GetClipboardCommand() []string
+	SetClipboardCommand(cmd []string)
+	CopyToClipboard(text string) error
+	GetKeySequenceTimeout() time.Duration
+	SetKeySequenceTimeout(d time.Duration)
 }
@@ -0,0 +1,85 @@
+// Copyright 2014 The gocui Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gocui
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ShowHelp creates, or updates, a view with the given name in the middle of
+// the screen, on top of the other views, that lists the keybindings of the
+// current input mode grouped by view. The view can be removed with
+// DeleteView.
+func (g *Gui) ShowHelp(name string) (Viewer, error) {
+	var (
+		groups []string
+		byView = make(map[string][]KeybindingInfo)
+		keysW  int
+	)
+	for _, kb := range g.Keybindings() {
+		if kb.Mode != "" && kb.Mode != g.mode {
+			continue
+		}
+		if _, ok := byView[kb.View]; !ok {
+			groups = append(groups, kb.View)
+		}
+		byView[kb.View] = append(byView[kb.View], kb)
+		if w := utf8.RuneCountInString(kb.KeysString()); w > keysW {
+			keysW = w
+		}
+	}
+	// global keybindings go first
+	for i, view := range groups {
+		if view == "" {
+			copy(groups[1:i+1], groups[:i])
+			groups[0] = ""
+			break
+		}
+	}
+
+	var lines []string
+	for i, view := range groups {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		if view == "" {
+			lines = append(lines, "Global")
+		} else {
+			lines = append(lines, view)
+		}
+		for _, kb := range byView[view] {
+			l := fmt.Sprintf("  %-*s  %s", keysW, kb.KeysString(), kb.Description)
+			lines = append(lines, strings.TrimRight(l, " "))
+		}
+	}
+
+	w := 0
+	for _, l := range lines {
+		if n := utf8.RuneCountInString(l); n > w {
+			w = n
+		}
+	}
+	w, h := w+1, len(lines)
+	if w > g.maxX-2 {
+		w = g.maxX - 2
+	}
+	if h > g.maxY-2 {
+		h = g.maxY - 2
+	}
+	x0, y0 := (g.maxX-w)/2-1, (g.maxY-h)/2-1
+
+	v, err := g.SetView(name, x0, y0, x0+w+1, y0+h+1)
+	if err != nil && err != ErrUnknownView {
+		return nil, err
+	}
+	v.Clear()
+	v.SetTitle("Help")
+	for _, l := range lines {
+		fmt.Fprintln(v, l)
+	}
+	return g.SetViewOnTop(name)
+}
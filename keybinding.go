@@ -6,30 +6,55 @@ package gocui
 
 import "github.com/thermeon/termbox-go"
 
-// Keybidings are used to link a given key-press event with a handler.
+// Keybidings are used to link a given key-press event, or a sequence of
+// them, with a handler.
 type keybinding struct {
-	viewName string
-	key      Key
-	ch       rune
-	mod      Modifier
-	handler  func(Guier, Viewer) error
+	viewName    string
+	keys        []KeyPress
+	predicate   *KeyPredicate
+	handler     func(Guier, Viewer, *Event) error
+	description string
+	mode        string
 }
 
 // newKeybinding returns a new Keybinding object.
-func newKeybinding(viewname string, key Key, ch rune, mod Modifier, handler func(Guier, Viewer) error) (kb *keybinding) {
+func newKeybinding(viewname string, keys []KeyPress, handler func(Guier, Viewer, *Event) error) (kb *keybinding) {
 	kb = &keybinding{
 		viewName: viewname,
-		key:      key,
-		ch:       ch,
-		mod:      mod,
+		keys:     keys,
 		handler:  handler,
 	}
 	return kb
 }
 
-// matchKeypress returns if the keybinding matches the keypress.
-func (kb *keybinding) matchKeypress(key Key, ch rune, mod Modifier) bool {
-	return kb.key == key && kb.ch == ch && kb.mod == mod
+// matchSequence returns if the keybinding matches the given sequence of
+// key-presses. If the sequence is only the beginning of the keybinding,
+// prefix is true.
+func (kb *keybinding) matchSequence(keys []KeyPress) (match, prefix bool) {
+	if kb.predicate != nil {
+		return len(keys) == 1 && keys[0].Mod == kb.keys[0].Mod && kb.predicate.Match(keys[0]), false
+	}
+	if len(keys) > len(kb.keys) {
+		return false, false
+	}
+	for i, kp := range keys {
+		if kb.keys[i] != kp {
+			return false, false
+		}
+	}
+	return len(keys) == len(kb.keys), len(keys) < len(kb.keys)
+}
+
+// sameKeys returns if the keybinding is triggered by the given sequence of
+// key-presses or, if pred is not nil, by the predicate with the same name.
+func (kb *keybinding) sameKeys(keys []KeyPress, pred *KeyPredicate) bool {
+	if (kb.predicate == nil) != (pred == nil) {
+		return false
+	}
+	if pred != nil && kb.predicate.Name != pred.Name {
+		return false
+	}
+	return sameKeys(kb.keys, keys)
 }
 
 // matchView returns if the keybinding matches the current view.
@@ -40,6 +65,74 @@ func (kb *keybinding) matchView(v Viewer) bool {
 	return v != nil && kb.viewName == v.Name()
 }
 
+// KeybindingInfo describes a keybinding. Keys contains a single key-press,
+// unless the keybinding is triggered by a sequence. If the keybinding is
+// triggered by a KeyPredicate, Keys is nil and Predicate contains its name,
+// prefixed by the modifier. Mode is "" (empty string) if the keybinding
+// applies to all input modes.
+type KeybindingInfo struct {
+	View        string
+	Mode        string
+	Keys        []KeyPress
+	Predicate   string
+	Description string
+}
+
+// KeysString returns the human-readable description of the keys that trigger
+// the keybinding.
+func (kb KeybindingInfo) KeysString() string {
+	if kb.Predicate != "" {
+		return kb.Predicate
+	}
+	return FormatKeySequence(kb.Keys)
+}
+
+// matchMode returns if the keybinding is active in the given input mode.
+func (kb *keybinding) matchMode(mode string) bool {
+	return kb.mode == "" || kb.mode == mode
+}
+
+// KeyPress represents a single key-press: a Key or a rune, combined with a
+// Modifier. Sequences of key-presses can be bound using SetKeySequence.
+type KeyPress struct {
+	Key Key
+	Ch  rune
+	Mod Modifier
+
+	// Paste is the text pasted by the user, in the key-presses of macros
+	// that record a paste. Key, Ch and Mod are not used then.
+	Paste string
+}
+
+// isRune returns if the key-press is a rune without modifiers, which is
+// usually text typed by the user.
+func (kp KeyPress) isRune() bool {
+	return kp.Ch != 0 && kp.Mod == ModNone
+}
+
+// isMouse returns if the key-press is a mouse event.
+func (kp KeyPress) isMouse() bool {
+	switch kp.Key {
+	case MouseLeft, MouseMiddle, MouseRight, MouseRelease, MouseWheelUp, MouseWheelDown,
+		MouseDragStart, MouseDrag, MouseDragEnd, MouseHover, MouseDoubleClick, MouseTripleClick:
+		return kp.Ch == 0
+	}
+	return false
+}
+
+// sameKeys returns if two sequences of key-presses are equal.
+func sameKeys(a, b []KeyPress) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 // Key represents special keys or keys combinations.
 type Key termbox.Key
 
@@ -76,6 +169,18 @@ const (
 	MouseWheelDown = Key(termbox.MouseWheelDown)
 )
 
+// Mouse events synthesized by gocui. Drag events are sent to the view where
+// the drag started, while hover events require Gui.Mouse and Gui.MouseMotion
+// to be enabled and a terminal that reports the motion of the mouse.
+const (
+	MouseDragStart Key = MouseWheelDown - 1 - iota
+	MouseDrag
+	MouseDragEnd
+	MouseHover
+	MouseDoubleClick
+	MouseTripleClick
+)
+
 // Keys combinations.
 const (
 	KeyCtrlTilde      Key = Key(termbox.KeyCtrlTilde)
@@ -0,0 +1,147 @@
+// Copyright 2014 The gocui Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gocui
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+)
+
+// A Keymap links named actions, like "quit" or "next-pane", with their
+// handlers and with the keys that trigger them. Applications register the
+// actions and their default bindings, users can override the bindings with a
+// JSON configuration, and Apply creates the resulting keybindings.
+type Keymap struct {
+	actions  map[string]func(Guier, Viewer) error
+	bindings []KeymapBinding
+}
+
+// KeymapBinding links a key specification (see ParseKeySequence) with an
+// action. If View equals to "" (empty string) then the binding will apply to
+// all views. If Mode equals to "" then the binding will apply to all input
+// modes.
+type KeymapBinding struct {
+	View   string `json:"view,omitempty"`
+	Mode   string `json:"mode,omitempty"`
+	Keys   string `json:"keys"`
+	Action string `json:"action"`
+}
+
+// NewKeymap returns a new, empty, Keymap.
+func NewKeymap() *Keymap {
+	return &Keymap{actions: make(map[string]func(Guier, Viewer) error)}
+}
+
+// SetAction registers the handler of an action. If the action already
+// exists, its handler is replaced.
+func (km *Keymap) SetAction(name string, handler func(Guier, Viewer) error) {
+	km.actions[name] = handler
+}
+
+// Bind adds a default binding of the given keys to an action.
+func (km *Keymap) Bind(viewname, keys, action string) error {
+	return km.BindMode("", viewname, keys, action)
+}
+
+// BindMode adds a default binding of the given keys to an action, that is
+// only active in the given input mode.
+func (km *Keymap) BindMode(mode, viewname, keys, action string) error {
+	if _, err := ParseKeySequence(keys); err != nil {
+		return err
+	}
+	km.bindings = append(km.bindings, KeymapBinding{View: viewname, Mode: mode, Keys: keys, Action: action})
+	return nil
+}
+
+// Bindings returns the bindings of the keymap.
+func (km *Keymap) Bindings() []KeymapBinding {
+	return km.bindings
+}
+
+// Load reads a JSON configuration from r, which must contain an array of
+// KeymapBindings:
+//
+//	[
+//		{"action": "quit", "keys": "ctrl+q"},
+//		{"action": "search", "view": "list", "keys": "/"},
+//		{"action": "insert", "mode": "normal", "keys": "i"}
+//	]
+//
+// For every action, view and mode present in the configuration, the existing
+// bindings are replaced by the configured ones. An empty "keys" removes the
+// bindings of the action.
+func (km *Keymap) Load(r io.Reader) error {
+	var conf []KeymapBinding
+	if err := json.NewDecoder(r).Decode(&conf); err != nil {
+		return err
+	}
+
+	type scope struct{ view, mode, action string }
+	overridden := make(map[scope]bool)
+	for _, b := range conf {
+		if b.Action == "" {
+			return errors.New("missing action in keymap configuration")
+		}
+		if b.Keys != "" {
+			if _, err := ParseKeySequence(b.Keys); err != nil {
+				return err
+			}
+		}
+		overridden[scope{b.View, b.Mode, b.Action}] = true
+	}
+
+	var bindings []KeymapBinding
+	for _, b := range km.bindings {
+		if !overridden[scope{b.View, b.Mode, b.Action}] {
+			bindings = append(bindings, b)
+		}
+	}
+	for _, b := range conf {
+		if b.Keys != "" {
+			bindings = append(bindings, b)
+		}
+	}
+	km.bindings = bindings
+	return nil
+}
+
+// LoadFile reads a JSON configuration from the file with the given name. See
+// Load for the format of the configuration.
+func (km *Keymap) LoadFile(name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return km.Load(f)
+}
+
+// Apply creates the keybindings of the keymap in the given GUI, using the
+// names of the actions as their descriptions. It returns an error if a
+// binding refers to an unknown action. Given that SetManager deletes all
+// keybindings, Apply must be called after it.
+func (km *Keymap) Apply(g Guier) error {
+	for _, b := range km.bindings {
+		handler, ok := km.actions[b.Action]
+		if !ok {
+			return errors.New("unknown action: " + b.Action)
+		}
+		var err error
+		if b.Mode == "" {
+			err = g.SetKeybinding(b.View, b.Keys, ModNone, handler)
+		} else {
+			err = g.SetModeKeybinding(b.Mode, b.View, b.Keys, ModNone, handler)
+		}
+		if err != nil {
+			return err
+		}
+		if err := g.SetKeybindingDescription(b.View, b.Keys, ModNone, b.Action); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+// Copyright 2014 The gocui Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gocui
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// keyNames links the names of the special keys with their Key. The first name
+// of each Key is used when formatting.
+var keyNames = []struct {
+	name string
+	key  Key
+}{
+	{"f1", KeyF1}, {"f2", KeyF2}, {"f3", KeyF3}, {"f4", KeyF4},
+	{"f5", KeyF5}, {"f6", KeyF6}, {"f7", KeyF7}, {"f8", KeyF8},
+	{"f9", KeyF9}, {"f10", KeyF10}, {"f11", KeyF11}, {"f12", KeyF12},
+	{"insert", KeyInsert}, {"ins", KeyInsert},
+	{"delete", KeyDelete}, {"del", KeyDelete},
+	{"home", KeyHome},
+	{"end", KeyEnd},
+	{"pgup", KeyPgup}, {"pageup", KeyPgup},
+	{"pgdn", KeyPgdn}, {"pagedown", KeyPgdn},
+	{"up", KeyArrowUp},
+	{"down", KeyArrowDown},
+	{"left", KeyArrowLeft},
+	{"right", KeyArrowRight},
+	{"enter", KeyEnter}, {"return", KeyEnter}, {"cr", KeyEnter},
+	{"tab", KeyTab},
+	{"esc", KeyEsc}, {"escape", KeyEsc},
+	{"backspace", KeyBackspace2}, {"bs", KeyBackspace2},
+	{"space", KeySpace},
+	{"mouseleft", MouseLeft},
+	{"mousemiddle", MouseMiddle},
+	{"mouseright", MouseRight},
+	{"mouserelease", MouseRelease},
+	{"wheelup", MouseWheelUp},
+	{"wheeldown", MouseWheelDown},
+	{"dragstart", MouseDragStart},
+	{"drag", MouseDrag},
+	{"dragend", MouseDragEnd},
+	{"hover", MouseHover},
+	{"doubleclick", MouseDoubleClick},
+	{"tripleclick", MouseTripleClick},
+}
+
+// ctrlKeyNames links the names of the keys that can be combined with Ctrl,
+// other than letters, with the resulting Key.
+var ctrlKeyNames = []struct {
+	name string
+	key  Key
+}{
+	{"space", KeyCtrlSpace}, {"2", KeyCtrl2}, {"~", KeyCtrlTilde},
+	{"[", KeyCtrlLsqBracket}, {"3", KeyCtrl3},
+	{"\\", KeyCtrlBackslash}, {"4", KeyCtrl4},
+	{"]", KeyCtrlRsqBracket}, {"5", KeyCtrl5},
+	{"6", KeyCtrl6},
+	{"/", KeyCtrlSlash}, {"_", KeyCtrlUnderscore}, {"7", KeyCtrl7},
+	{"8", KeyCtrl8},
+}
+
+// modifierNames links the prefixes that can be used in key specifications
+// with the modifier they represent.
+var modifierNames = []struct {
+	name string
+	ctrl bool
+	mod  Modifier
+}{
+	{"ctrl", true, ModNone}, {"control", true, ModNone}, {"c", true, ModNone},
+	{"alt", false, ModAlt}, {"meta", false, ModAlt}, {"m", false, ModAlt}, {"a", false, ModAlt},
+}
+
+// ParseKey parses a human-readable key specification, like "ctrl+s",
+// "alt+enter", "F5", "<pgdn>" or "q", into a KeyPress. Modifiers can be
+// separated from the key by '+' or '-', and the whole specification can be
+// enclosed in angle brackets. Names are case insensitive, but single runes
+// are not.
+func ParseKey(spec string) (KeyPress, error) {
+	s := spec
+	if len(s) > 2 && s[0] == '<' && s[len(s)-1] == '>' {
+		s = s[1 : len(s)-1]
+	}
+	if s == "" {
+		return KeyPress{}, errors.New("empty key specification")
+	}
+
+	var (
+		kp   KeyPress
+		ctrl bool
+	)
+	for {
+		i := strings.IndexAny(s, "+-")
+		if i <= 0 || i == len(s)-1 {
+			break
+		}
+		found := false
+		for _, mn := range modifierNames {
+			if strings.EqualFold(s[:i], mn.name) {
+				ctrl = ctrl || mn.ctrl
+				kp.Mod |= mn.mod
+				found = true
+				break
+			}
+		}
+		if !found {
+			return KeyPress{}, errors.New("unknown modifier in key specification: " + spec)
+		}
+		s = s[i+1:]
+	}
+
+	if ctrl {
+		if utf8.RuneCountInString(s) == 1 {
+			ch := []rune(strings.ToLower(s))[0]
+			if ch >= 'a' && ch <= 'z' {
+				kp.Key = KeyCtrlA + Key(ch-'a')
+				return kp, nil
+			}
+		}
+		for _, kn := range ctrlKeyNames {
+			if strings.EqualFold(s, kn.name) {
+				kp.Key = kn.key
+				return kp, nil
+			}
+		}
+		return KeyPress{}, errors.New("invalid key after ctrl in key specification: " + spec)
+	}
+
+	if utf8.RuneCountInString(s) == 1 {
+		ch := []rune(s)[0]
+		if ch == ' ' {
+			kp.Key = KeySpace
+		} else {
+			kp.Ch = ch
+		}
+		return kp, nil
+	}
+	for _, kn := range keyNames {
+		if strings.EqualFold(s, kn.name) {
+			kp.Key = kn.key
+			return kp, nil
+		}
+	}
+	return KeyPress{}, errors.New("unknown key in key specification: " + spec)
+}
+
+// ParseKeySequence parses a sequence of human-readable key specifications
+// separated by spaces, like "g g" or "ctrl+x ctrl+s". See ParseKey for the
+// format of each key specification.
+func ParseKeySequence(spec string) ([]KeyPress, error) {
+	fields := strings.Fields(spec)
+	if len(fields) == 0 {
+		return nil, errors.New("empty key specification")
+	}
+
+	keys := make([]KeyPress, len(fields))
+	for i, f := range fields {
+		kp, err := ParseKey(f)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = kp
+	}
+	return keys, nil
+}
+
+// String returns the human-readable specification of the key-press, in the
+// format accepted by ParseKey. Recorded pastes are formatted as "<paste>".
+func (kp KeyPress) String() string {
+	if kp.Paste != "" {
+		return "<paste>"
+	}
+
+	str := ""
+	if kp.Mod&ModAlt != 0 {
+		str += "alt+"
+	}
+
+	if kp.Ch != 0 {
+		if kp.Ch == ' ' {
+			return str + "space"
+		}
+		return str + string(kp.Ch)
+	}
+
+	for _, kn := range keyNames {
+		if kn.key == kp.Key {
+			return str + kn.name
+		}
+	}
+	if kp.Key >= KeyCtrlA && kp.Key <= KeyCtrlZ {
+		return str + "ctrl+" + string(rune('a'+kp.Key-KeyCtrlA))
+	}
+	for _, kn := range ctrlKeyNames {
+		if kn.key == kp.Key {
+			return str + "ctrl+" + kn.name
+		}
+	}
+	return str + "unknown"
+}
+
+// FormatKeySequence returns the human-readable specification of a sequence
+// of key-presses, in the format accepted by ParseKeySequence.
+func FormatKeySequence(keys []KeyPress) string {
+	names := make([]string, len(keys))
+	for i, kp := range keys {
+		names[i] = kp.String()
+	}
+	return strings.Join(names, " ")
+}
@@ -0,0 +1,91 @@
+// Copyright 2014 The gocui Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gocui
+
+import (
+	"strings"
+	"unicode"
+)
+
+// A KeyPredicate can be passed to SetKeybinding, and the rest of functions
+// that accept a key, to create a keybinding that is triggered by any
+// key-press for which Match returns true, e.g. any digit or any printable
+// rune. The modifier of the key-press must also match the one passed along
+// with the predicate. Name describes the keys in the help view and identifies
+// the keybinding when it is deleted.
+//
+// Keybindings of predicates always consist of a single key-press, and exact
+// keybindings take precedence over them.
+type KeyPredicate struct {
+	Name  string
+	Match func(KeyPress) bool
+}
+
+// AnyKey matches any key-press, other than mouse events.
+var AnyKey = KeyPredicate{
+	Name: "<any>",
+	Match: func(kp KeyPress) bool {
+		return !kp.isMouse()
+	},
+}
+
+// AnyRune matches any rune.
+var AnyRune = KeyPredicate{
+	Name: "<rune>",
+	Match: func(kp KeyPress) bool {
+		return kp.Ch != 0
+	},
+}
+
+// PrintableRune matches any printable rune, as defined by unicode.IsPrint.
+var PrintableRune = KeyPredicate{
+	Name: "<printable>",
+	Match: func(kp KeyPress) bool {
+		return kp.Ch != 0 && unicode.IsPrint(kp.Ch)
+	},
+}
+
+// RuneRange returns a KeyPredicate that matches the runes between from and
+// to, both included. For instance, RuneRange('0', '9') matches any digit.
+func RuneRange(from, to rune) KeyPredicate {
+	return KeyPredicate{
+		Name: "[" + string(from) + "-" + string(to) + "]",
+		Match: func(kp KeyPress) bool {
+			return kp.Ch != 0 && kp.Ch >= from && kp.Ch <= to
+		},
+	}
+}
+
+// RunesIn returns a KeyPredicate that matches any of the runes in s.
+func RunesIn(s string) KeyPredicate {
+	return KeyPredicate{
+		Name: "[" + s + "]",
+		Match: func(kp KeyPress) bool {
+			return kp.Ch != 0 && strings.ContainsRune(s, kp.Ch)
+		},
+	}
+}
+
+// KeysIn returns a KeyPredicate that matches any of the given keys.
+func KeysIn(keys ...Key) KeyPredicate {
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = KeyPress{Key: k}.String()
+	}
+	return KeyPredicate{
+		Name: "[" + strings.Join(names, " ") + "]",
+		Match: func(kp KeyPress) bool {
+			if kp.Ch != 0 {
+				return false
+			}
+			for _, k := range keys {
+				if kp.Key == k {
+					return true
+				}
+			}
+			return false
+		},
+	}
+}
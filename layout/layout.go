@@ -0,0 +1,297 @@
+// Copyright 2014 The gocui Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package layout implements a declarative layout engine for gocui.
+
+Instead of computing the coordinates of every view from the size of the
+terminal, the layout is described as a tree of rows and columns, whose
+children have fixed, percentage or fractional sizes, optionally limited by a
+minimum and a maximum:
+
+	l := layout.New(layout.Column(layout.Fraction(1),
+		layout.View("header", layout.Fixed(3)),
+		layout.Row(layout.Fraction(1),
+			layout.View("side", layout.Percent(25).Min(20)),
+			layout.View("main", layout.Fraction(1)),
+		),
+		layout.View("log", layout.Fraction(1).Max(10)),
+	))
+	g.SetManager(l)
+
+A Layout is a gocui.Manager. Every time the GUI is redrawn, it assigns the
+bounds of the views with SetView. Views that do not fit in the terminal are
+deleted.
+*/
+package layout
+
+import "github.com/thermeon/gocui"
+
+// sizeKind is the kind of a Size.
+type sizeKind int
+
+// Kinds of sizes.
+const (
+	fixed sizeKind = iota
+	percent
+	fraction
+)
+
+// Size is the size of a node along the main axis of its container: the
+// width for the children of a row and the height for the children of a
+// column.
+type Size struct {
+	kind     sizeKind
+	value    int
+	min, max int
+}
+
+// Fixed returns a Size of n cells.
+func Fixed(n int) Size {
+	return Size{kind: fixed, value: n}
+}
+
+// Percent returns a Size of p percent of the container.
+func Percent(p int) Size {
+	return Size{kind: percent, value: p}
+}
+
+// Fraction returns a Size that takes a share of the space left by the fixed
+// and percentage sizes of the container. The space is shared among the
+// fractional sizes in proportion to n.
+func Fraction(n int) Size {
+	return Size{kind: fraction, value: n}
+}
+
+// Min returns a copy of s that is never smaller than n cells, as long as
+// there is enough space in the container.
+func (s Size) Min(n int) Size {
+	s.min = n
+	return s
+}
+
+// Max returns a copy of s that is never larger than n cells.
+func (s Size) Max(n int) Size {
+	s.max = n
+	return s
+}
+
+// clamp limits n to the minimum and maximum of the size.
+func (s Size) clamp(n int) int {
+	if s.max > 0 && n > s.max {
+		n = s.max
+	}
+	if n < s.min {
+		n = s.min
+	}
+	if n < 0 {
+		n = 0
+	}
+	return n
+}
+
+// Node is an element of a layout: a view or a container of nodes.
+type Node interface {
+	// size returns the size of the node in its container.
+	size() Size
+
+	// layout assigns the given bounds to the node. Bounds are inclusive
+	// and can be empty, i.e. x1 < x0 or y1 < y0.
+	layout(g gocui.Guier, x0, y0, x1, y1 int) error
+}
+
+// ViewNode is a node of the layout that represents a view.
+type ViewNode struct {
+	name string
+	sz   Size
+	init func(gocui.Viewer) error
+}
+
+// View returns a node for the view with the given name.
+func View(name string, size Size) *ViewNode {
+	return &ViewNode{name: name, sz: size}
+}
+
+// Init sets a function that is called when the view is created, to
+// initialize it, and returns the node.
+func (n *ViewNode) Init(f func(gocui.Viewer) error) *ViewNode {
+	n.init = f
+	return n
+}
+
+func (n *ViewNode) size() Size {
+	return n.sz
+}
+
+func (n *ViewNode) layout(g gocui.Guier, x0, y0, x1, y1 int) error {
+	// a view needs at least two cells in each direction
+	if x1 <= x0 || y1 <= y0 {
+		if err := g.DeleteView(n.name); err != nil && err != gocui.ErrUnknownView {
+			return err
+		}
+		return nil
+	}
+
+	v, err := g.SetView(n.name, x0, y0, x1, y1)
+	if err != gocui.ErrUnknownView {
+		return err
+	}
+	if n.init != nil {
+		return n.init(v)
+	}
+	return nil
+}
+
+// Container is a node of the layout that arranges its children in a row or
+// in a column.
+type Container struct {
+	horizontal bool
+	sz         Size
+	children   []Node
+}
+
+// Row returns a container that arranges its children from left to right.
+func Row(size Size, children ...Node) *Container {
+	return &Container{horizontal: true, sz: size, children: children}
+}
+
+// Column returns a container that arranges its children from top to bottom.
+func Column(size Size, children ...Node) *Container {
+	return &Container{sz: size, children: children}
+}
+
+func (c *Container) size() Size {
+	return c.sz
+}
+
+func (c *Container) layout(g gocui.Guier, x0, y0, x1, y1 int) error {
+	total := y1 - y0 + 1
+	if c.horizontal {
+		total = x1 - x0 + 1
+	}
+	if total < 0 {
+		total = 0
+	}
+
+	sizes := make([]Size, len(c.children))
+	for i, child := range c.children {
+		sizes[i] = child.size()
+	}
+	cells := distribute(total, sizes)
+
+	pos := y0
+	if c.horizontal {
+		pos = x0
+	}
+	for i, child := range c.children {
+		var err error
+		if c.horizontal {
+			err = child.layout(g, pos, y0, pos+cells[i]-1, y1)
+		} else {
+			err = child.layout(g, x0, pos, x1, pos+cells[i]-1)
+		}
+		if err != nil {
+			return err
+		}
+		pos += cells[i]
+	}
+	return nil
+}
+
+// distribute splits total cells among the given sizes. Fixed and percentage
+// sizes are assigned first, and the remaining cells are shared among the
+// fractional sizes. If the sizes do not fit, the last ones are reduced.
+func distribute(total int, sizes []Size) []int {
+	cells := make([]int, len(sizes))
+	used := 0
+	var free []int // fractional sizes that have not been assigned yet
+	for i, s := range sizes {
+		switch s.kind {
+		case fixed:
+			cells[i] = s.clamp(s.value)
+		case percent:
+			cells[i] = s.clamp(total * s.value / 100)
+		default:
+			free = append(free, i)
+			continue
+		}
+		used += cells[i]
+	}
+
+	remaining := total - used
+	for len(free) > 0 {
+		if remaining < 0 {
+			remaining = 0
+		}
+		weights := 0
+		for _, i := range free {
+			if sizes[i].value > 0 {
+				weights += sizes[i].value
+			}
+		}
+
+		// share the remaining cells, giving the rounding leftovers to the
+		// first sizes
+		shared := 0
+		for _, i := range free {
+			cells[i] = 0
+			if weights > 0 && sizes[i].value > 0 {
+				cells[i] = remaining * sizes[i].value / weights
+			}
+			shared += cells[i]
+		}
+		for _, i := range free {
+			if shared >= remaining || weights == 0 {
+				break
+			}
+			if sizes[i].value > 0 {
+				cells[i]++
+				shared++
+			}
+		}
+
+		// sizes limited by their minimum or maximum keep it, and the rest
+		// of cells are shared again among the others
+		var next []int
+		for _, i := range free {
+			if n := sizes[i].clamp(cells[i]); n != cells[i] {
+				cells[i] = n
+				remaining -= n
+			} else {
+				next = append(next, i)
+			}
+		}
+		if len(next) == len(free) {
+			break
+		}
+		free = next
+	}
+
+	pos := 0
+	for i := range cells {
+		if cells[i] > total-pos {
+			cells[i] = total - pos
+		}
+		pos += cells[i]
+	}
+	return cells
+}
+
+// Layout is a gocui.Manager that lays out a tree of nodes in the whole
+// terminal.
+type Layout struct {
+	root Node
+}
+
+// New returns a Layout whose root node takes the whole terminal. The size of
+// the root node is ignored.
+func New(root Node) *Layout {
+	return &Layout{root: root}
+}
+
+// Layout assigns the bounds of all the views of the layout.
+func (l *Layout) Layout(g gocui.Guier) error {
+	maxX, maxY := g.Size()
+	return l.root.layout(g, 0, 0, maxX-1, maxY-1)
+}
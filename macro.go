@@ -0,0 +1,99 @@
+// Copyright 2014 The gocui Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gocui
+
+import "errors"
+
+// StartRecording starts recording the key-presses typed by the user into the
+// given register. The key-press that starts the recording is not recorded.
+func (g *Gui) StartRecording(register string) error {
+	if register == "" {
+		return errors.New("invalid register")
+	}
+	if g.recording != "" {
+		return errors.New("already recording")
+	}
+	g.recording = register
+	g.recordedKeys = nil
+	return nil
+}
+
+// StopRecording stops recording and stores the recorded key-presses in the
+// register, replacing its previous contents. The key-presses that stop the
+// recording are not recorded.
+func (g *Gui) StopRecording() error {
+	if g.recording == "" {
+		return errors.New("not recording")
+	}
+
+	keys := g.recordedKeys
+	// when the recording is stopped by a sequence, all its key-presses but
+	// the last one have been already recorded
+	if n := len(g.execKeys) - 1; n > 0 && n <= len(keys) && sameKeys(keys[len(keys)-n:], g.execKeys[:n]) {
+		keys = keys[:len(keys)-n]
+	}
+
+	if g.macros == nil {
+		g.macros = make(map[string][]KeyPress)
+	}
+	g.macros[g.recording] = keys
+	g.recording = ""
+	g.recordedKeys = nil
+	return nil
+}
+
+// Recording returns the register that is being recorded, or "" (empty string)
+// if there is no recording in progress.
+func (g *Gui) Recording() string {
+	return g.recording
+}
+
+// Macro returns the key-presses stored in the given register.
+func (g *Gui) Macro(register string) []KeyPress {
+	return g.macros[register]
+}
+
+// SetMacro stores a sequence of key-presses in the given register, which
+// allows to restore macros saved by the application.
+func (g *Gui) SetMacro(register string, keys []KeyPress) {
+	if g.macros == nil {
+		g.macros = make(map[string][]KeyPress)
+	}
+	s := make([]KeyPress, len(keys))
+	copy(s, keys)
+	g.macros[register] = s
+}
+
+// ReplayMacro replays the key-presses stored in the given register the given
+// number of times. They are handled exactly like the key-presses typed by
+// the user, so they trigger keybindings and are passed to the editor of the
+// current view, while recorded pastes are inserted without triggering
+// keybindings. Replaying a macro while another one is replaying, e.g. if the
+// macro contains the key-presses that replay it, does nothing.
+func (g *Gui) ReplayMacro(register string, times int) error {
+	keys, ok := g.macros[register]
+	if !ok {
+		return errors.New("unknown register")
+	}
+	if g.replaying {
+		return nil
+	}
+
+	g.replaying = true
+	defer func() { g.replaying = false }()
+
+	for i := 0; i < times; i++ {
+		for _, kp := range keys {
+			if kp.Paste != "" {
+				g.paste(kp.Paste)
+				continue
+			}
+			if err := g.onKeyPress(newKeyEvent(kp)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
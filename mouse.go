@@ -0,0 +1,155 @@
+// Copyright 2014 The gocui Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gocui
+
+import "github.com/thermeon/termbox-go"
+
+// modMotion is set by termbox in the mouse events produced by the motion of
+// the pointer.
+const modMotion = Modifier(termbox.ModMotion)
+
+// Escape sequences that enable and disable the reporting of every motion of
+// the mouse, even if no button is pressed, which is needed for hover events.
+const (
+	mouseMotionOn  = "\x1b[?1003h"
+	mouseMotionOff = "\x1b[?1003l"
+)
+
+// enableMouseMotion tells the terminal to enable or disable the tracking of
+// the motion of the mouse.
+func (g *Gui) enableMouseMotion(enable bool) {
+	if enable == g.motionEnabled {
+		return
+	}
+	if enable {
+		writeTerminal(mouseMotionOn)
+	} else {
+		writeTerminal(mouseMotionOff)
+	}
+	g.motionEnabled = enable
+}
+
+// onMouse handles a mouse event. Besides the events reported by the terminal,
+// it synthesizes drag, hover and multiple click events. Drag events are
+// dispatched to the view where the drag started, the rest of events to the
+// view under the pointer.
+func (g *Gui) onMouse(e *Event) error {
+	motion := e.Mod&modMotion != 0
+	e.Mod &^= modMotion
+
+	switch {
+	case motion && e.Key == MouseRelease:
+		// motion without any button pressed
+		v, err := g.ViewByPosition(e.MouseX, e.MouseY)
+		if err != nil {
+			return nil
+		}
+		return g.execMouse(v, MouseHover, e)
+	case motion:
+		if g.mouseDown == nil {
+			return nil
+		}
+		e.DragX, e.DragY = g.mouseDown.MouseX, g.mouseDown.MouseY
+		if g.frameDrag != nil {
+			return g.dragFrame(e)
+		}
+		if !g.dragging {
+			g.dragging = true
+			if err := g.execMouse(g.dragView, MouseDragStart, e); err != nil {
+				return err
+			}
+		}
+		return g.execMouse(g.dragView, MouseDrag, e)
+	case e.Key == MouseRelease:
+		if g.dragging {
+			de := *e
+			de.DragX, de.DragY = g.mouseDown.MouseX, g.mouseDown.MouseY
+			if err := g.execMouse(g.dragView, MouseDragEnd, &de); err != nil {
+				return err
+			}
+			// a drag is not a click
+			g.lastClick = nil
+		}
+		g.endDrag()
+	}
+
+	if e.Key == MouseLeft && g.startFrameDrag(e) {
+		g.mouseDown, g.dragView, g.dragging = e, nil, false
+		return nil
+	}
+
+	g.countClicks(e)
+	v, err := g.ViewByPosition(e.MouseX, e.MouseY)
+	if err != nil {
+		return nil
+	}
+	if e.Clicks > 0 {
+		g.mouseDown, g.dragView, g.dragging = e, v, false
+		if err := g.focusOnClick(v); err != nil {
+			return err
+		}
+	}
+	g.setViewPosition(v, e)
+	if e.Key == MouseLeft && v.IsSelectable() {
+		v.ClearSelection()
+	}
+	if err := v.SetCursor(e.ViewX, e.ViewY); err != nil {
+		return err
+	}
+	matched, err := g.clickRegion(v, e)
+	if err != nil || matched {
+		return err
+	}
+	matched, err = g.execKeybindings(v, []KeyPress{e.keyPress()}, e)
+	if err != nil {
+		return err
+	}
+	if !matched && v.GetWheelLines() > 0 {
+		switch e.Key {
+		case MouseWheelUp:
+			v.Scroll(-v.GetWheelLines())
+		case MouseWheelDown:
+			v.Scroll(v.GetWheelLines())
+		}
+	}
+
+	if e.Key == MouseLeft {
+		switch e.Clicks {
+		case 2:
+			return g.execMouse(v, MouseDoubleClick, e)
+		case 3:
+			return g.execMouse(v, MouseTripleClick, e)
+		}
+	}
+	return nil
+}
+
+// endDrag forgets the mouse button press and the drag in progress, if any.
+func (g *Gui) endDrag() {
+	g.mouseDown, g.dragView, g.dragging, g.frameDrag = nil, nil, false, nil
+}
+
+// execMouse executes the keybindings of a mouse event synthesized from e,
+// with the given key.
+func (g *Gui) execMouse(v Viewer, key Key, e *Event) error {
+	if v == nil {
+		return nil
+	}
+	se := *e
+	se.Key = key
+	g.setViewPosition(v, &se)
+	switch key {
+	case MouseDragStart, MouseDrag, MouseDragEnd:
+		mouseSelection(v, &se)
+	}
+	_, err := g.execKeybindings(v, []KeyPress{se.keyPress()}, &se)
+	return err
+}
+
+// setViewPosition sets the position of the mouse relative to the view v.
+func (g *Gui) setViewPosition(v Viewer, e *Event) {
+	x0, y0, _, _ := v.GetBounds()
+	e.ViewX, e.ViewY = e.MouseX-x0-1, e.MouseY-y0-1
+}
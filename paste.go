@@ -0,0 +1,207 @@
+// Copyright 2014 The gocui Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gocui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/thermeon/termbox-go"
+)
+
+// Escape sequences of the bracketed paste mode. When it is enabled, the
+// terminal surrounds the pasted text with the start and end markers.
+const (
+	bracketedPasteOn  = "\x1b[?2004h"
+	bracketedPasteOff = "\x1b[?2004l"
+	pasteStart        = "\x1b[200~"
+	pasteEnd          = "\x1b[201~"
+)
+
+// pasteTimeout is the time to wait for the rest of an incomplete paste marker
+// before giving up. Once the start marker has been received, the paste only
+// finishes with the end marker, however long the terminal takes to send it.
+const pasteTimeout = 50 * time.Millisecond
+
+// enableBracketedPaste tells the terminal to enable or disable the bracketed
+// paste mode.
+func (g *Gui) enableBracketedPaste(enable bool) {
+	if enable == g.pasteEnabled {
+		return
+	}
+	if enable {
+		writeTerminal(bracketedPasteOn)
+	} else {
+		writeTerminal(bracketedPasteOff)
+	}
+	g.pasteEnabled = enable
+}
+
+// onPasteKey handles a key-press event when the bracketed paste mode is
+// enabled. termbox delivers the paste markers as a sequence of key-presses,
+// so they are held back until it is known whether they form a marker. The
+// key-presses between the markers are collected and pasted at once, without
+// triggering any keybinding.
+func (g *Gui) onPasteKey(ev *termbox.Event) error {
+	raw, ok := rawKey(ev)
+	if !ok {
+		if err := g.flushPaste(); err != nil {
+			return err
+		}
+		if g.pasting {
+			return nil
+		}
+		return g.onKey(ev)
+	}
+
+	marker := pasteStart
+	if g.pasting {
+		marker = pasteEnd
+	}
+	seq := g.pasteMarker + raw
+	switch {
+	case seq == marker:
+		g.pasteMarker = ""
+		g.pasteHeld = nil
+		if !g.pasting {
+			g.pasting = true
+			g.pasteText = ""
+			return nil
+		}
+		return g.endPaste()
+	case strings.HasPrefix(marker, seq):
+		g.pasteMarker = seq
+		g.pasteHeld = append(g.pasteHeld, *ev)
+		g.restartPasteTimeout()
+		return nil
+	case g.pasteMarker != "":
+		// the held key-presses were not a marker, but ev could be the
+		// beginning of a new one
+		if err := g.flushPaste(); err != nil {
+			return err
+		}
+		return g.onPasteKey(ev)
+	case g.pasting:
+		g.pasteText += raw
+		return nil
+	default:
+		return g.onKey(ev)
+	}
+}
+
+// flushPaste handles the key-presses held back because they could have been
+// the beginning of a paste marker.
+func (g *Gui) flushPaste() error {
+	held := g.pasteHeld
+	if g.pasting {
+		g.pasteText += g.pasteMarker
+	}
+	g.pasteMarker = ""
+	g.pasteHeld = nil
+	if g.pasting {
+		return nil
+	}
+	for i := range held {
+		if err := g.onKey(&held[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// finishPaste handles the key-presses held back and the text pasted so far
+// when the bracketed paste mode is disabled.
+func (g *Gui) finishPaste() error {
+	if err := g.flushPaste(); err != nil {
+		return err
+	}
+	if g.pasting {
+		return g.endPaste()
+	}
+	return nil
+}
+
+// restartPasteTimeout makes sure that the key-presses held back are handled
+// if the terminal does not send the rest of a marker in time.
+func (g *Gui) restartPasteTimeout() {
+	g.pasteDeadline = time.Now().Add(pasteTimeout)
+	if g.pasteTimer != nil {
+		g.pasteTimer.Reset(pasteTimeout)
+		return
+	}
+	g.pasteTimer = time.AfterFunc(pasteTimeout, func() {
+		g.userEvents <- userEvent{f: func(Guier) error {
+			if time.Now().Before(g.pasteDeadline) {
+				// the timer has been restarted since it fired
+				return nil
+			}
+			return g.flushPaste()
+		}}
+	})
+}
+
+// endPaste passes the collected text to the current view.
+func (g *Gui) endPaste() error {
+	text := strings.Replace(g.pasteText, "\r\n", "\n", -1)
+	text = strings.Replace(text, "\r", "\n", -1)
+	g.pasting = false
+	g.pasteText = ""
+	if g.recording != "" {
+		g.recordedKeys = append(g.recordedKeys, KeyPress{Paste: text})
+	}
+	g.paste(text)
+	return nil
+}
+
+// paste inserts text in the current view, if it is editable. If its editor is
+// a PasteEditor, it receives the whole text. Otherwise, the text is passed
+// to the editor rune by rune.
+func (g *Gui) paste(text string) {
+	v := g.currentView
+	if text == "" || v == nil || !v.IsEditable() || v.GetEditor() == nil {
+		return
+	}
+	if pe, ok := v.GetEditor().(PasteEditor); ok {
+		pe.Paste(v, text)
+		return
+	}
+	for _, ch := range text {
+		switch ch {
+		case '\n':
+			v.GetEditor().Edit(v, KeyEnter, 0, ModNone)
+		case '\t':
+			v.GetEditor().Edit(v, KeyTab, 0, ModNone)
+		default:
+			v.GetEditor().Edit(v, 0, ch, ModNone)
+		}
+	}
+}
+
+// rawKey returns the characters sent by the terminal for a key-press event,
+// as far as they are part of a paste: runes, whitespace and escape
+// sequences. The value of ok is false for any other event.
+func rawKey(ev *termbox.Event) (raw string, ok bool) {
+	if ev.Type != termbox.EventKey {
+		return "", false
+	}
+	if ev.Mod&termbox.ModAlt != 0 {
+		raw = "\x1b"
+	}
+	switch {
+	case ev.Ch != 0:
+		return raw + string(ev.Ch), true
+	case ev.Key == termbox.KeyEsc:
+		return raw + "\x1b", true
+	case ev.Key == termbox.KeyEnter:
+		return raw + "\r", true
+	case ev.Key == termbox.KeyCtrlJ:
+		return raw + "\n", true
+	case ev.Key == termbox.KeyTab:
+		return raw + "\t", true
+	case ev.Key == termbox.KeySpace:
+		return raw + " ", true
+	}
+	return "", false
+}
@@ -0,0 +1,142 @@
+// Copyright 2014 The gocui Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gocui
+
+import "io"
+
+// A Region is a named area of the internal buffer of a View, from the point
+// (X0, Y0) to the point (X1, Y1), both included, whose Handler is called when
+// it is clicked. Given that regions are tracked in buffer coordinates, they
+// scroll with the content of the view.
+type Region struct {
+	Name           string
+	X0, Y0, X1, Y1 int
+	Handler        func(Guier, Viewer, *Event) error
+}
+
+// contains returns if the point (x, y) of the buffer is inside the region.
+func (r Region) contains(x, y int) bool {
+	if y < r.Y0 || y > r.Y1 {
+		return false
+	}
+	return (y > r.Y0 || x >= r.X0) && (y < r.Y1 || x <= r.X1)
+}
+
+// SetRegion creates a clickable region in the view's internal buffer, or
+// replaces the region with the same name. handler is called when the region
+// is clicked with the left mouse button, before any keybinding. If it returns
+// ErrPropagate, the click is also handled by the keybindings. If regions
+// overlap, the most recent one gets the click.
+func (v *View) SetRegion(name string, x0, y0, x1, y1 int, handler func(Guier, Viewer, *Event) error) {
+	v.DeleteRegion(name)
+	v.regions = append(v.regions, Region{Name: name, X0: x0, Y0: y0, X1: x1, Y1: y1, Handler: handler})
+}
+
+// WriteRegion appends text to the view's internal buffer, like Write, and
+// creates a clickable region that covers it. See SetRegion.
+func (v *View) WriteRegion(name, text string, handler func(Guier, Viewer, *Event) error) error {
+	x0, y0 := v.endPosition()
+	if _, err := io.WriteString(v, text); err != nil {
+		return err
+	}
+	x1, y1 := v.endPosition()
+	if x1 == 0 {
+		// text ends with a new line
+		if y1--; y1 < 0 {
+			return nil
+		}
+		x1 = len(v.lines[y1])
+	}
+	v.SetRegion(name, x0, y0, x1-1, y1, handler)
+	return nil
+}
+
+// endPosition returns the point of the buffer where Write adds the next
+// rune.
+func (v *View) endPosition() (x, y int) {
+	if len(v.lines) == 0 {
+		return 0, 0
+	}
+	y = len(v.lines) - 1
+	return len(v.lines[y]), y
+}
+
+// DeleteRegion deletes the region with the given name.
+func (v *View) DeleteRegion(name string) {
+	for i, r := range v.regions {
+		if r.Name == name {
+			v.regions = append(v.regions[:i], v.regions[i+1:]...)
+			return
+		}
+	}
+}
+
+// ClearRegions deletes all the regions of the view. Clear also deletes them.
+func (v *View) ClearRegions() {
+	v.regions = nil
+}
+
+// Regions returns the regions of the view, in the order they were created.
+func (v *View) Regions() []Region {
+	rs := make([]Region, len(v.regions))
+	copy(rs, v.regions)
+	return rs
+}
+
+// RegionAt returns the most recent region that contains the point (x, y) of
+// the view's internal buffer.
+func (v *View) RegionAt(x, y int) (Region, bool) {
+	for i := len(v.regions) - 1; i >= 0; i-- {
+		if v.regions[i].contains(x, y) {
+			return v.regions[i], true
+		}
+	}
+	return Region{}, false
+}
+
+// clickRegion calls the handler of the region under the mouse pointer, if
+// any, and sets the name of the region in the event. It returns true if the
+// click has been handled.
+func (g *Gui) clickRegion(v Viewer, e *Event) (bool, error) {
+	x, y, err := v.BufferPosition(e.ViewX, e.ViewY)
+	if err != nil {
+		return false, nil
+	}
+	r, ok := v.RegionAt(x, y)
+	if !ok {
+		return false, nil
+	}
+	e.Region = r.Name
+	if e.Key != MouseLeft || r.Handler == nil {
+		return false, nil
+	}
+	switch err := r.Handler(g, v, e); err {
+	case nil:
+		return true, nil
+	case ErrPropagate:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// shiftRegions updates the regions after replacing n lines of the buffer,
+// starting at the line y, with added lines. The regions in the replaced
+// lines are deleted.
+func (v *View) shiftRegions(y, n, added int) {
+	var rs []Region
+	for _, r := range v.regions {
+		switch {
+		case r.Y1 < y:
+		case r.Y0 >= y+n:
+			r.Y0 += added - n
+			r.Y1 += added - n
+		default:
+			continue
+		}
+		rs = append(rs, r)
+	}
+	v.regions = rs
+}
@@ -0,0 +1,152 @@
+// Copyright 2014 The gocui Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gocui
+
+import "strings"
+
+// BufferPosition returns the position in the internal buffer corresponding to
+// the point (x, y) of the view, taking into account its origin and whether
+// lines are wrapped.
+func (v *View) BufferPosition(x, y int) (bx, by int, err error) {
+	return v.realPosition(x, y)
+}
+
+// SetSelection selects the text between the points (x0, y0) and (x1, y1),
+// both included, given in buffer coordinates. (x0, y0) is the anchor of the
+// selection, which is kept by ExtendSelection.
+func (v *View) SetSelection(x0, y0, x1, y1 int) {
+	v.selActive = true
+	v.selX0, v.selY0, v.selX1, v.selY1 = x0, y0, x1, y1
+}
+
+// ExtendSelection moves the end of the selection to the point (x, y), in
+// buffer coordinates, keeping its anchor. If there is no selection, it starts
+// at the cursor position.
+func (v *View) ExtendSelection(x, y int) {
+	if !v.selActive {
+		cx, cy, err := v.realPosition(v.cx, v.cy)
+		if err != nil {
+			cx, cy = x, y
+		}
+		v.SetSelection(cx, cy, x, y)
+		return
+	}
+	v.selX1, v.selY1 = x, y
+}
+
+// ClearSelection removes the selection of the view.
+func (v *View) ClearSelection() {
+	v.selActive = false
+}
+
+// Selection returns the beginning and the end of the selection, both
+// included, in buffer coordinates. The value of ok is false if there is no
+// selection.
+func (v *View) Selection() (x0, y0, x1, y1 int, ok bool) {
+	if !v.selActive {
+		return 0, 0, 0, 0, false
+	}
+	x0, y0, x1, y1 = v.selX0, v.selY0, v.selX1, v.selY1
+	if y1 < y0 || (y1 == y0 && x1 < x0) {
+		x0, y0, x1, y1 = x1, y1, x0, y0
+	}
+	return x0, y0, x1, y1, true
+}
+
+// SelectedText returns the selected text. Lines are separated by '\n'.
+func (v *View) SelectedText() string {
+	x0, y0, x1, y1, ok := v.Selection()
+	if !ok {
+		return ""
+	}
+
+	var lines []string
+	for y := y0; y <= y1 && y < len(v.lines); y++ {
+		if y < 0 {
+			continue
+		}
+		line := v.lines[y]
+		from, to := 0, len(line)
+		if y == y0 && x0 > from {
+			from = x0
+		}
+		if y == y1 && x1+1 < to {
+			to = x1 + 1
+		}
+		var s []rune
+		for x := from; x < to; x++ {
+			s = append(s, line[x].chr)
+		}
+		lines = append(lines, string(s))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// inSelection returns if the point (x, y) of the buffer is selected.
+func (v *View) inSelection(x, y int) bool {
+	x0, y0, x1, y1, ok := v.Selection()
+	if !ok || y < y0 || y > y1 {
+		return false
+	}
+	return (y > y0 || x >= x0) && (y < y1 || x <= x1)
+}
+
+// shiftSelection updates the selection after replacing n lines of the buffer,
+// starting at the line y, with added lines. The selection is cleared if any
+// of its lines is replaced.
+func (v *View) shiftSelection(y, n, added int) {
+	_, y0, _, y1, ok := v.Selection()
+	switch {
+	case !ok || y1 < y:
+	case y0 >= y+n:
+		v.selY0 += added - n
+		v.selY1 += added - n
+	default:
+		v.ClearSelection()
+	}
+}
+
+// selectionColors returns the colors of a selected cell. Unless the selection
+// colors of the view are set, the colors of the cell are reversed.
+func (v *View) selectionColors(fgColor, bgColor Attribute) (Attribute, Attribute) {
+	if v.SelectionFgColor == ColorDefault && v.SelectionBgColor == ColorDefault {
+		return fgColor | AttrReverse, bgColor
+	}
+	return v.SelectionFgColor, v.SelectionBgColor
+}
+
+// mouseSelection updates the selection of a selectable view while the mouse
+// is dragged over it. The view coordinates of the event are clamped to the
+// view, so the selection follows the pointer when it leaves the view.
+func mouseSelection(v Viewer, e *Event) {
+	if !v.IsSelectable() {
+		return
+	}
+	x0, y0, _, _ := v.GetBounds()
+	maxX, maxY := v.Size()
+	clamp := func(n, max int) int {
+		if n >= max {
+			n = max - 1
+		}
+		if n < 0 {
+			n = 0
+		}
+		return n
+	}
+
+	x, y, err := v.BufferPosition(clamp(e.ViewX, maxX), clamp(e.ViewY, maxY))
+	if err != nil {
+		return
+	}
+	if e.Key == MouseDragStart {
+		sx, sy, err := v.BufferPosition(clamp(e.DragX-x0-1, maxX), clamp(e.DragY-y0-1, maxY))
+		if err != nil {
+			return
+		}
+		v.SetSelection(sx, sy, x, y)
+		return
+	}
+	v.ExtendSelection(x, y)
+}
@@ -29,6 +29,15 @@ type View struct {
 
 	ei *escapeInterpreter // used to decode ESC sequences on Write
 
+	autoscrollPaused bool // Autoscroll disabled while scrolled up
+
+	regions []Region // clickable regions, in buffer coordinates
+
+	focusInHandler, focusOutHandler func(Guier, Viewer) error
+
+	selActive                  bool
+	selX0, selY0, selX1, selY1 int // anchor and end of the selection, in buffer coordinates
+
 	// BgColor and FgColor allow to configure the background and foreground
 	// colors of the View.
 	BgColor, FgColor Attribute
@@ -71,6 +80,40 @@ type View struct {
 	// If Mask is true, the View will display the mask instead of the real
 	// content
 	Mask rune
+
+	// If Resizable is true, the View can be resized by dragging the edges of
+	// its frame with the mouse.
+	Resizable bool
+
+	// If Movable is true, the View can be moved by dragging the top edge of
+	// its frame with the mouse.
+	Movable bool
+
+	// If Focusable is false, the View is skipped by Gui.FocusNext and
+	// Gui.FocusPrevious, and does not get the focus when it is clicked and
+	// the focus policy of the GUI is FocusFocusable. Views are focusable by
+	// default.
+	Focusable bool
+
+	// TabIndex determines the position of the View in the focus order used
+	// by Gui.FocusNext and Gui.FocusPrevious. Views with the same TabIndex
+	// follow the order of the views in the GUI. If it is negative, the View
+	// is skipped.
+	TabIndex int
+
+	// If WheelLines is greater than 0, the View scrolls that number of lines
+	// for every tick of the mouse wheel, unless a keybinding handles the
+	// wheel events.
+	WheelLines int
+
+	// If Selectable is true, text can be selected by dragging the mouse over
+	// the View.
+	Selectable bool
+
+	// SelectionBgColor and SelectionFgColor are used to configure the
+	// background and foreground colors of the selected text. If both are
+	// ColorDefault, the colors of the selected text are reversed.
+	SelectionBgColor, SelectionFgColor Attribute
 }
 
 func (v *View) SetFrame(f bool) {
@@ -103,6 +146,7 @@ func (v *View) GetAutoscroll() bool {
 
 func (v *View) SetAutoscroll(b bool) {
 	v.Autoscroll = b
+	v.autoscrollPaused = false
 }
 
 func (v *View) GetOverwrite() bool {
@@ -150,18 +194,67 @@ func (l lineType) String() string {
 	return str
 }
 
+// StyledRun is a piece of text whose cells share the same colors and
+// attributes. ColorDefault means that the colors of the View are used.
+type StyledRun struct {
+	Text             string
+	FgColor, BgColor Attribute
+}
+
+// runs returns the StyledRuns that compose a given cell slice.
+func (l lineType) runs() []StyledRun {
+	var runs []StyledRun
+	for _, c := range l {
+		ch := c.chr
+		if ch == 0 {
+			ch = ' '
+		}
+		if n := len(runs); n > 0 && runs[n-1].FgColor == c.fgColor && runs[n-1].BgColor == c.bgColor {
+			runs[n-1].Text += string(ch)
+			continue
+		}
+		runs = append(runs, StyledRun{Text: string(ch), FgColor: c.fgColor, BgColor: c.bgColor})
+	}
+	return runs
+}
+
+// ansi returns a string from a given cell slice, where colors and attributes
+// are encoded as ANSI escape sequences. The style is reset at the end of the
+// line, so the result can be written back to a View to obtain the same cells.
+func (l lineType) ansi() string {
+	str := ""
+	styled := false
+	for _, r := range l.runs() {
+		if r.FgColor == ColorDefault && r.BgColor == ColorDefault {
+			if styled {
+				str += escapeReset
+				styled = false
+			}
+		} else {
+			str += escapeSequence(r.FgColor, r.BgColor)
+			styled = true
+		}
+		str += r.Text
+	}
+	if styled {
+		str += escapeReset
+	}
+	return str
+}
+
 // newView returns a new View object.
 func newView(name string, x0, y0, x1, y1 int, mode OutputMode) Viewer {
 	v := &View{
-		name:    name,
-		x0:      x0,
-		y0:      y0,
-		x1:      x1,
-		y1:      y1,
-		Frame:   true,
-		Editor:  DefaultEditor,
-		tainted: true,
-		ei:      newEscapeInterpreter(mode),
+		name:      name,
+		x0:        x0,
+		y0:        y0,
+		x1:        x1,
+		y1:        y1,
+		Frame:     true,
+		Editor:    DefaultEditor,
+		Focusable: true,
+		tainted:   true,
+		ei:        newEscapeInterpreter(mode),
 	}
 	return v
 }
@@ -208,6 +301,11 @@ func (v *View) setRune(x, y int, ch rune, fgColor, bgColor Attribute) error {
 		fgColor = v.SelFgColor
 		bgColor = v.SelBgColor
 	}
+	if v.selActive {
+		if sx, sy, err := v.realPosition(x, y); err == nil && v.inSelection(sx, sy) {
+			fgColor, bgColor = v.selectionColors(fgColor, bgColor)
+		}
+	}
 
 	termbox.SetCell(v.x0+x+1, v.y0+y+1, ch,
 		termbox.Attribute(fgColor), termbox.Attribute(bgColor))
@@ -246,6 +344,40 @@ func (v *View) SetOrigin(x, y int) error {
 	return nil
 }
 
+// Scroll scrolls the view dy lines, down if dy is positive and up if it is
+// negative, without going past the beginning or the end of the buffer. If
+// Autoscroll is enabled, scrolling up disables it until the view is scrolled
+// back to the bottom.
+func (v *View) Scroll(dy int) {
+	v.updateViewLines()
+	_, maxY := v.Size()
+	bottom := len(v.viewLines) - maxY
+	if bottom < 0 {
+		bottom = 0
+	}
+	if v.Autoscroll {
+		v.oy = bottom
+	}
+
+	y := v.oy + dy
+	if y > bottom {
+		y = bottom
+	}
+	if y < 0 {
+		y = 0
+	}
+	v.oy = y
+
+	switch {
+	case v.Autoscroll && y < bottom:
+		v.Autoscroll = false
+		v.autoscrollPaused = true
+	case v.autoscrollPaused && y == bottom:
+		v.Autoscroll = true
+		v.autoscrollPaused = false
+	}
+}
+
 // Origin returns the origin position of the view.
 func (v *View) Origin() (x, y int) {
 	return v.ox, v.oy
@@ -270,7 +402,7 @@ func (v *View) Write(p []byte) (n int, err error) {
 				v.lines = make([][]cell, 1)
 			}
 		default:
-			cells := v.parseInput(ch)
+			cells := v.parseInput(v.ei, ch)
 			if cells == nil {
 				continue
 			}
@@ -286,15 +418,15 @@ func (v *View) Write(p []byte) (n int, err error) {
 	return len(p), nil
 }
 
-// parseInput parses char by char the input written to the View. It returns nil
-// while processing ESC sequences. Otherwise, it returns a cell slice that
-// contains the processed data.
-func (v *View) parseInput(ch rune) []cell {
+// parseInput parses char by char the input written to the View, using the
+// given escapeInterpreter. It returns nil while processing ESC sequences.
+// Otherwise, it returns a cell slice that contains the processed data.
+func (v *View) parseInput(ei *escapeInterpreter, ch rune) []cell {
 	cells := []cell{}
 
-	isEscape, err := v.ei.parseOne(ch)
+	isEscape, err := ei.parseOne(ch)
 	if err != nil {
-		for _, r := range v.ei.runes() {
+		for _, r := range ei.runes() {
 			c := cell{
 				fgColor: v.FgColor,
 				bgColor: v.BgColor,
@@ -302,14 +434,14 @@ func (v *View) parseInput(ch rune) []cell {
 			}
 			cells = append(cells, c)
 		}
-		v.ei.reset()
+		ei.reset()
 	} else {
 		if isEscape {
 			return nil
 		}
 		c := cell{
-			fgColor: v.ei.curFgColor,
-			bgColor: v.ei.curBgColor,
+			fgColor: ei.curFgColor,
+			bgColor: ei.curBgColor,
 			chr:     ch,
 		}
 		cells = append(cells, c)
@@ -350,32 +482,7 @@ func (v *View) draw() error {
 		}
 		v.ox = 0
 	}
-	if v.tainted {
-		v.viewLines = nil
-		for i, line := range v.lines {
-			if v.Wrap {
-				if len(line) < maxX {
-					vline := viewLine{linesX: 0, linesY: i, line: line}
-					v.viewLines = append(v.viewLines, vline)
-					continue
-				} else {
-					for n := 0; n <= len(line); n += maxX {
-						if len(line[n:]) <= maxX {
-							vline := viewLine{linesX: n, linesY: i, line: line[n:]}
-							v.viewLines = append(v.viewLines, vline)
-						} else {
-							vline := viewLine{linesX: n, linesY: i, line: line[n : n+maxX]}
-							v.viewLines = append(v.viewLines, vline)
-						}
-					}
-				}
-			} else {
-				vline := viewLine{linesX: 0, linesY: i, line: line}
-				v.viewLines = append(v.viewLines, vline)
-			}
-		}
-		v.tainted = false
-	}
+	v.updateViewLines()
 
 	if v.Autoscroll && len(v.viewLines) > maxY {
 		v.oy = len(v.viewLines) - maxY
@@ -416,6 +523,39 @@ func (v *View) draw() error {
 	return nil
 }
 
+// updateViewLines updates the internal representation of the view's buffer,
+// wrapping the lines if needed, when the buffer has changed.
+func (v *View) updateViewLines() {
+	maxX, _ := v.Size()
+	if !v.tainted || (v.Wrap && maxX <= 0) {
+		return
+	}
+	v.viewLines = nil
+	for i, line := range v.lines {
+		if v.Wrap {
+			if len(line) < maxX {
+				vline := viewLine{linesX: 0, linesY: i, line: line}
+				v.viewLines = append(v.viewLines, vline)
+				continue
+			} else {
+				for n := 0; n <= len(line); n += maxX {
+					if len(line[n:]) <= maxX {
+						vline := viewLine{linesX: n, linesY: i, line: line[n:]}
+						v.viewLines = append(v.viewLines, vline)
+					} else {
+						vline := viewLine{linesX: n, linesY: i, line: line[n : n+maxX]}
+						v.viewLines = append(v.viewLines, vline)
+					}
+				}
+			}
+		} else {
+			vline := viewLine{linesX: 0, linesY: i, line: line}
+			v.viewLines = append(v.viewLines, vline)
+		}
+	}
+	v.tainted = false
+}
+
 // realPosition returns the position in the internal buffer corresponding to the
 // point (x, y) of the view.
 func (v *View) realPosition(vx, vy int) (x, y int, err error) {
@@ -443,6 +583,132 @@ func (v *View) realPosition(vx, vy int) (x, y int, err error) {
 	return x, y, nil
 }
 
+// LineCount returns the number of lines in the view's internal buffer.
+func (v *View) LineCount() int {
+	return len(v.lines)
+}
+
+// SetLine replaces the line y of the view's internal buffer with the given
+// text. y is the index of the line in the internal buffer, not a point of the
+// view.
+func (v *View) SetLine(y int, text string) error {
+	return v.ReplaceLines(y, 1, text)
+}
+
+// InsertLines inserts the given lines before the line y of the view's
+// internal buffer. If y equals LineCount(), the lines are appended.
+func (v *View) InsertLines(y int, lines ...string) error {
+	return v.ReplaceLines(y, 0, lines...)
+}
+
+// DeleteLines deletes n lines of the view's internal buffer, starting at the
+// line y.
+func (v *View) DeleteLines(y, n int) error {
+	return v.ReplaceLines(y, n)
+}
+
+// ReplaceLines replaces n lines of the view's internal buffer, starting at
+// the line y, with the given lines. Each line is parsed like the input passed
+// to Write, but ESC sequences do not affect the rest of the buffer. The cursor
+// is kept on the same line of the buffer when it is not replaced, and the
+// origin is adjusted so the cursor stays visible.
+func (v *View) ReplaceLines(y, n int, lines ...string) error {
+	if y < 0 || n < 0 || y+n > len(v.lines) {
+		return errors.New("invalid point")
+	}
+
+	var cells [][]cell
+	for _, l := range lines {
+		cells = append(cells, v.parseLines(l)...)
+	}
+
+	// the cursor is in view coordinates, which differ from the buffer ones
+	// when lines are wrapped
+	v.updateViewLines()
+	curX, cur, err := v.realPosition(v.cx, v.cy)
+	if err != nil {
+		curX, cur = v.ox+v.cx, v.oy+v.cy
+	}
+	if cur < len(v.lines) { // the cursor is not beyond the end of the buffer
+		if cur >= y+n {
+			cur += len(cells) - n
+		} else if cur >= y {
+			cur = y
+		}
+	}
+
+	s := make([][]cell, 0, len(v.lines)-n+len(cells))
+	s = append(s, v.lines[:y]...)
+	s = append(s, cells...)
+	s = append(s, v.lines[y+n:]...)
+	v.lines = s
+	v.shiftRegions(y, n, len(cells))
+	v.shiftSelection(y, n, len(cells))
+
+	v.tainted = true
+	v.updateViewLines()
+	v.setCursorLine(curX, cur)
+	return nil
+}
+
+// parseLines parses a string the same way Write does, using its own
+// escapeInterpreter, and returns the resulting lines.
+func (v *View) parseLines(s string) [][]cell {
+	ei := newEscapeInterpreter(v.ei.mode)
+	lines := [][]cell{nil}
+	for _, ch := range s {
+		nl := len(lines)
+		switch ch {
+		case '\n':
+			lines = append(lines, nil)
+		case '\r':
+			lines[nl-1] = nil
+		default:
+			lines[nl-1] = append(lines[nl-1], v.parseInput(ei, ch)...)
+		}
+	}
+	return lines
+}
+
+// setCursorLine places the cursor on the line y of the view's internal
+// buffer, clamped to the existing lines, scrolling the view if necessary. If
+// the line is wrapped, the cursor is placed on the part that contains the
+// point x of the line.
+func (v *View) setCursorLine(x, y int) {
+	_, maxY := v.Size()
+	if y >= len(v.lines) {
+		y = len(v.lines) - 1
+	}
+	if y < 0 {
+		y = 0
+	}
+	if !v.tainted {
+		// convert y to a line of the view
+		vy := -1
+		for i, vline := range v.viewLines {
+			if vline.linesY != y {
+				if vy >= 0 {
+					break
+				}
+				continue
+			}
+			if vy >= 0 && vline.linesX > x {
+				break
+			}
+			vy = i
+		}
+		if vy >= 0 {
+			y = vy
+		}
+	}
+	if y < v.oy {
+		v.oy = y
+	} else if maxY > 0 && y-v.oy >= maxY {
+		v.oy = y - maxY + 1
+	}
+	v.cy = y - v.oy
+}
+
 // Clear empties the view's internal buffer.
 func (v *View) Clear() {
 	v.tainted = true
@@ -450,6 +716,8 @@ func (v *View) Clear() {
 	v.lines = nil
 	v.viewLines = nil
 	v.readOffset = 0
+	v.selActive = false
+	v.regions = nil
 	v.clearRunes()
 }
 
@@ -528,6 +796,102 @@ func (v *View) Word(x, y int) (string, error) {
 	return string(str[nl:nr]), nil
 }
 
+// StyledBuffer returns the contents of the view's internal buffer as a slice
+// of lines, each one composed by StyledRuns.
+func (v *View) StyledBuffer() [][]StyledRun {
+	var lines [][]StyledRun
+	for _, l := range v.lines {
+		lines = append(lines, lineType(l).runs())
+	}
+	return lines
+}
+
+// StyledViewBuffer returns the contents of the view's buffer that is shown to
+// the user as a slice of lines, each one composed by StyledRuns.
+func (v *View) StyledViewBuffer() [][]StyledRun {
+	var lines [][]StyledRun
+	for _, l := range v.viewLines {
+		lines = append(lines, lineType(l.line).runs())
+	}
+	return lines
+}
+
+// StyledLine returns the StyledRuns of the line of the view's internal buffer
+// at the position corresponding to the point (x, y).
+func (v *View) StyledLine(y int) ([]StyledRun, error) {
+	_, y, err := v.realPosition(0, y)
+	if err != nil {
+		return nil, err
+	}
+
+	if y < 0 || y >= len(v.lines) {
+		return nil, errors.New("invalid point")
+	}
+
+	return lineType(v.lines[y]).runs(), nil
+}
+
+// StyledWord returns the StyledRuns of the word of the view's internal buffer
+// at the position corresponding to the point (x, y).
+func (v *View) StyledWord(x, y int) ([]StyledRun, error) {
+	x, y, err := v.realPosition(x, y)
+	if err != nil {
+		return nil, err
+	}
+
+	if x < 0 || y < 0 || y >= len(v.lines) || x >= len(v.lines[y]) {
+		return nil, errors.New("invalid point")
+	}
+
+	line := v.lines[y]
+	nl := x
+	for nl > 0 && !indexFunc(line[nl-1].chr) {
+		nl--
+	}
+	nr := x
+	for nr < len(line) && !indexFunc(line[nr].chr) {
+		nr++
+	}
+	return lineType(line[nl:nr]).runs(), nil
+}
+
+// ANSIBuffer returns a string with the contents of the view's internal
+// buffer, where colors and attributes are encoded as ANSI escape sequences.
+func (v *View) ANSIBuffer() string {
+	str := ""
+	for _, l := range v.lines {
+		str += lineType(l).ansi() + "\n"
+	}
+	return str
+}
+
+// ANSIViewBuffer returns a string with the contents of the view's buffer that
+// is shown to the user, where colors and attributes are encoded as ANSI
+// escape sequences.
+func (v *View) ANSIViewBuffer() string {
+	str := ""
+	for _, l := range v.viewLines {
+		str += lineType(l.line).ansi() + "\n"
+	}
+	return str
+}
+
+// ANSILine returns a string with the line of the view's internal buffer at
+// the position corresponding to the point (x, y), where colors and
+// attributes are encoded as ANSI escape sequences.
+func (v *View) ANSILine(y int) (string, error) {
+	_, y, err := v.realPosition(0, y)
+	if err != nil {
+		return "", err
+	}
+
+	if y < 0 || y >= len(v.lines) {
+		return "", errors.New("invalid point")
+	}
+
+	return lineType(v.lines[y]).ansi(), nil
+}
+
 // indexFunc allows to split lines by words taking into account spaces
 // and 0.
 func indexFunc(r rune) bool {
@@ -562,6 +926,89 @@ func (v *View) IsEditable() bool {
 	return v.Editable
 }
 
+func (v *View) IsResizable() bool {
+	return v.Resizable
+}
+
+func (v *View) SetResizable(r bool) {
+	v.Resizable = r
+}
+
+func (v *View) IsMovable() bool {
+	return v.Movable
+}
+
+func (v *View) SetMovable(m bool) {
+	v.Movable = m
+}
+
+func (v *View) IsFocusable() bool {
+	return v.Focusable
+}
+
+func (v *View) SetFocusable(f bool) {
+	v.Focusable = f
+}
+
+func (v *View) GetTabIndex() int {
+	return v.TabIndex
+}
+
+func (v *View) SetTabIndex(i int) {
+	v.TabIndex = i
+}
+
+// SetFocusInHandler sets the function that is called when the view gets the
+// focus.
+func (v *View) SetFocusInHandler(handler func(Guier, Viewer) error) {
+	v.focusInHandler = handler
+}
+
+// SetFocusOutHandler sets the function that is called when the view loses
+// the focus.
+func (v *View) SetFocusOutHandler(handler func(Guier, Viewer) error) {
+	v.focusOutHandler = handler
+}
+
+// FocusIn is called by the GUI when the view gets the focus, and calls the
+// focus-in handler.
+func (v *View) FocusIn(g Guier) error {
+	if v.focusInHandler != nil {
+		return v.focusInHandler(g, v)
+	}
+	return nil
+}
+
+// FocusOut is called by the GUI when the view loses the focus, and calls the
+// focus-out handler.
+func (v *View) FocusOut(g Guier) error {
+	if v.focusOutHandler != nil {
+		return v.focusOutHandler(g, v)
+	}
+	return nil
+}
+
+func (v *View) GetWheelLines() int {
+	return v.WheelLines
+}
+
+func (v *View) SetWheelLines(n int) {
+	v.WheelLines = n
+}
+
+func (v *View) IsSelectable() bool {
+	return v.Selectable
+}
+
+func (v *View) SetSelectable(s bool) {
+	v.Selectable = s
+}
+
+func (v *View) SetSelectionBgFgColor(bg Attribute, fg Attribute) {
+	v.SelectionBgColor = bg
+	v.SelectionFgColor = fg
+}
+
 func (v *View) GetBounds() (x0, y0, x1, y1 int) {
 	return v.x0, v.y0, v.x1, v.y1
 }
@@ -21,10 +21,22 @@ type Viewer interface {
 	io.Reader
 	Rewind()
 	Clear()
+	LineCount() int
+	SetLine(y int, text string) error
+	InsertLines(y int, lines ...string) error
+	DeleteLines(y, n int) error
+	ReplaceLines(y, n int, lines ...string) error
 	Buffer() string
 	ViewBuffer() string
 	Line(y int) (string, error)
 	Word(x, y int) (string, error)
+	StyledBuffer() [][]StyledRun
+	StyledViewBuffer() [][]StyledRun
+	StyledLine(y int) ([]StyledRun, error)
+	StyledWord(x, y int) ([]StyledRun, error)
+	ANSIBuffer() string
+	ANSIViewBuffer() string
+	ANSILine(y int) (string, error)
 	Invalidate()
 	HasFrame() bool
 	SetFrame(f bool)
@@ -54,4 +66,34 @@ type Viewer interface {
 	SetWrap(b bool)
 	GetAutoscroll() bool
 	SetAutoscroll(b bool)
+	IsResizable() bool
+	SetResizable(r bool)
+	IsMovable() bool
+	SetMovable(m bool)
+	IsFocusable() bool
+	SetFocusable(f bool)
+	GetTabIndex() int
+	SetTabIndex(i int)
+	SetFocusInHandler(handler func(Guier, Viewer) error)
+	SetFocusOutHandler(handler func(Guier, Viewer) error)
+	FocusIn(g Guier) error
+	FocusOut(g Guier) error
+	GetWheelLines() int
+	SetWheelLines(n int)
+	Scroll(dy int)
+	IsSelectable() bool
+	SetSelectable(s bool)
+	SetSelectionBgFgColor(bg Attribute, fg Attribute)
+	BufferPosition(x, y int) (bx, by int, err error)
+	SetSelection(x0, y0, x1, y1 int)
+	ExtendSelection(x, y int)
+	ClearSelection()
+	Selection() (x0, y0, x1, y1 int, ok bool)
+	SelectedText() string
+	SetRegion(name string, x0, y0, x1, y1 int, handler func(Guier, Viewer, *Event) error)
+	WriteRegion(name, text string, handler func(Guier, Viewer, *Event) error) error
+	DeleteRegion(name string)
+	ClearRegions()
+	Regions() []Region
+	RegionAt(x, y int) (Region, bool)
 }